@@ -0,0 +1,121 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Assignment assigns a value to the location referred to by a JSON pointer,
+// such as one given by a command-line flag.
+type Assignment struct {
+	Pointer Pointer
+
+	// Value is the JSON encoding of the value being assigned.
+	Value json.RawMessage
+}
+
+// ParseAssignment parses an assignment of the form "pointer=value", where
+// value is JSON, or "pointer:=value", where value is a string that is used
+// as is. The pointer ends at the first "=" in s, so it cannot refer to an
+// object member whose name contains "=".
+func ParseAssignment(s string) (Assignment, error) {
+	ptr, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return Assignment{}, &invalidAssignmentError{s}
+	}
+
+	str := strings.HasSuffix(ptr, ":")
+	if str {
+		ptr = ptr[:len(ptr)-1]
+	}
+
+	p, err := Parse(ptr)
+	if err != nil {
+		return Assignment{}, err
+	}
+
+	a := Assignment{
+		Pointer: p,
+	}
+
+	if str {
+		a.Value, err = json.Marshal(value)
+		if err != nil {
+			return Assignment{}, err
+		}
+	} else {
+		if !json.Valid([]byte(value)) {
+			return Assignment{}, &invalidAssignmentError{s}
+		}
+
+		a.Value = json.RawMessage(value)
+	}
+
+	return a, nil
+}
+
+// Apply applies the assignment to doc, as if by [Pointer.SetCreate] with the
+// [CreateArrays] option. The value is decoded into the type of the location
+// being set, as if by [json.Unmarshal].
+func (a Assignment) Apply(doc any) error {
+	if err := a.Pointer.SetCreate(doc, encodedValue(a.Value), CreateArrays()); err != nil {
+		return &applyError{a.String(), err}
+	}
+
+	return nil
+}
+
+// String returns the assignment in the form "pointer=value".
+func (a Assignment) String() string {
+	return a.Pointer.String() + "=" + string(a.Value)
+}
+
+// Assignments is a list of assignments. A pointer to Assignments implements
+// [flag.Value], so that it can hold the assignments given by a repeated
+// command-line flag:
+//
+//	var overrides jsonpointer.Assignments
+//	flag.Var(&overrides, "set", "set `pointer=value` in the configuration")
+type Assignments []Assignment
+
+// Apply applies each of the assignments to doc in turn, as if by
+// [Assignment.Apply]. Apply continues after an assignment fails, and returns
+// the errors from every failed assignment joined together.
+func (a Assignments) Apply(doc any) error {
+	var errs []error
+	for _, assignment := range a {
+		if err := assignment.Apply(doc); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Set parses the assignment s, as if by [ParseAssignment], and appends it to
+// the list.
+func (a *Assignments) Set(s string) error {
+	assignment, err := ParseAssignment(s)
+	if err != nil {
+		return err
+	}
+
+	*a = append(*a, assignment)
+	return nil
+}
+
+// String returns the assignments in the form "pointer=value", separated by
+// spaces.
+func (a Assignments) String() string {
+	var b strings.Builder
+	for i, assignment := range a {
+		if i != 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(assignment.String())
+	}
+
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package jsonpointer
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestParseAssignment(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		s     string
+		ptr   string
+		value string
+	}
+
+	tests := []test{
+		{"/db/port=5432", "/db/port", "5432"},
+		{`/features/-={"name":"x"}`, "/features/-", `{"name":"x"}`},
+		{"/tags/0:=string", "/tags/0", `"string"`},
+		{"/a~1b:=c=d", "/a~1b", `"c=d"`},
+		{"/e:=", "/e", `""`},
+	}
+
+	for _, test := range tests {
+		a, err := ParseAssignment(test.s)
+		if err != nil {
+			t.Fatalf("ParseAssignment(%s) = %v, want <nil>", test.s, err)
+		}
+
+		if a.Pointer.String() != test.ptr || string(a.Value) != test.value {
+			t.Errorf("ParseAssignment(%s) = %s, want %s=%s", test.s, a, test.ptr, test.value)
+		}
+	}
+
+	for _, s := range []string{"/a", "/a=", "/a=b", "a=1"} {
+		_, err := ParseAssignment(s)
+		if !errors.Is(err, ErrInvalidAssignment) && !errors.Is(err, ErrInvalidPointer) {
+			t.Errorf("ParseAssignment(%s) = %v, want %v", s, err, ErrInvalidAssignment)
+		}
+	}
+}
+
+func TestAssignmentsApply(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	var overrides Assignments
+	fs.Var(&overrides, "set", "")
+
+	err := fs.Parse([]string{
+		"-set", "/db/port=5432",
+		"-set", `/features/-={"name":"x"}`,
+		"-set", "/tags/0:=string",
+	})
+	if err != nil {
+		t.Fatalf("FlagSet.Parse() = %v, want <nil>", err)
+	}
+
+	want := `/db/port=5432 /features/-={"name":"x"} /tags/0="string"`
+	if overrides.String() != want {
+		t.Errorf("Assignments.String() = %s, want %s", overrides.String(), want)
+	}
+
+	var doc any
+	if err := overrides.Apply(&doc); err != nil {
+		t.Fatalf("Assignments.Apply() = %v, want <nil>", err)
+	}
+
+	wantDoc := map[string]any{
+		"db": map[string]any{
+			"port": 5432.0,
+		},
+		"features": []any{
+			map[string]any{
+				"name": "x",
+			},
+		},
+		"tags": []any{
+			"string",
+		},
+	}
+
+	if !reflect.DeepEqual(doc, wantDoc) {
+		t.Errorf("Assignments.Apply() = %v, want %v", doc, wantDoc)
+	}
+
+	type Feature struct {
+		Name string `json:"name"`
+	}
+
+	type Config struct {
+		DB struct {
+			Port uint16 `json:"port"`
+		} `json:"db"`
+		Features []Feature `json:"features"`
+		Tags     []string  `json:"tags"`
+	}
+
+	var cfg Config
+	if err := overrides.Apply(&cfg); err != nil {
+		t.Fatalf("Assignments.Apply() = %v, want <nil>", err)
+	}
+
+	if cfg.DB.Port != 5432 || len(cfg.Features) != 1 || cfg.Features[0].Name != "x" || len(cfg.Tags) != 1 || cfg.Tags[0] != "string" {
+		t.Errorf("Assignments.Apply() = %+v, want {{5432} [{x}] [string]}", cfg)
+	}
+
+	// An existing array index inserts before the element, as the "add"
+	// operation of RFC 6902 does.
+	var insert Assignments
+	if err := insert.Set("/tags/0:=first"); err != nil {
+		t.Fatalf("Assignments.Set() = %v, want <nil>", err)
+	}
+
+	if err := insert.Apply(&doc); err != nil {
+		t.Fatalf("Assignments.Apply() = %v, want <nil>", err)
+	}
+
+	if tags, want := doc.(map[string]any)["tags"], []any{"first", "string"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Assignments.Apply() = %v, want %v", tags, want)
+	}
+
+	if err := insert.Apply(&cfg); err != nil {
+		t.Fatalf("Assignments.Apply() = %v, want <nil>", err)
+	}
+
+	if want := []string{"first", "string"}; !reflect.DeepEqual(cfg.Tags, want) {
+		t.Errorf("Assignments.Apply() = %v, want %v", cfg.Tags, want)
+	}
+
+	overrides = nil
+	for _, s := range []string{"/db/port=70000", "/db/port:=1", "/missing=1", "/tags/0=2"} {
+		if err := overrides.Set(s); err != nil {
+			t.Fatalf("Assignments.Set(%s) = %v, want <nil>", s, err)
+		}
+	}
+
+	err = overrides.Apply(&cfg)
+	if !errors.Is(err, ErrTypeMismatch) || !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Assignments.Apply() = %v, want %v and %v", err, ErrTypeMismatch, ErrValueNotFound)
+	}
+
+	if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 4 {
+		t.Errorf("Assignments.Apply() returned %d errors, want 4", len(errs))
+	}
+}
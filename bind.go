@@ -0,0 +1,174 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type bindField struct {
+	index      int
+	name       string
+	tag        string
+	ptr        Pointer
+	required   bool
+	hasDefault bool
+	def        string
+	err        error
+}
+
+var bindFieldsCache sync.Map
+
+func getBindFields(t reflect.Type) []bindField {
+	if fields, ok := bindFieldsCache.Load(t); ok {
+		return fields.([]bindField)
+	}
+
+	var fields []bindField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("jsonpointer")
+		if !ok || !sf.IsExported() {
+			continue
+		}
+
+		f := bindField{
+			index: i,
+			name:  sf.Name,
+			tag:   tag,
+		}
+
+		ptr, opts, _ := strings.Cut(tag, ",")
+		f.ptr, f.err = Parse(ptr)
+		for opts != "" {
+			var opt string
+			if strings.HasPrefix(opts, "default=") {
+				opt, opts = opts, ""
+			} else {
+				opt, opts, _ = strings.Cut(opts, ",")
+			}
+
+			switch {
+			case opt == "required":
+				f.required = true
+			case strings.HasPrefix(opt, "default="):
+				f.hasDefault = true
+				f.def = opt[len("default="):]
+			default:
+				if f.err == nil {
+					f.err = &invalidTagOptionError{opt}
+				}
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	fieldsVal, _ := bindFieldsCache.LoadOrStore(t, fields)
+	return fieldsVal.([]bindField)
+}
+
+// Bind sets the fields of the struct pointed to by dst from doc. Each field
+// with a tag of the form `jsonpointer:"/a/b"` is set to the value that the
+// JSON pointer in the tag resolves to in doc. Fields without a tag, and
+// unexported fields, are left unchanged.
+//
+// Values are converted to the types of fields where this can be done without
+// losing information, as they are by [Pointer.Set]. Strings are also decoded
+// as JSON into fields of other types, numbers and bools are encoded as JSON
+// into fields of string types, and objects and arrays are converted to the
+// types of fields as if by [json.Unmarshal].
+//
+// The pointer in a tag may be followed by options, separated by commas:
+//
+//   - "required" causes Bind to return an error if the pointer does not
+//     resolve to a value in doc.
+//   - "default=value" sets the field to value if the pointer does not resolve
+//     to a value in doc, converting it as if it were a string in doc. It must
+//     be the last option, and value may contain commas.
+//
+// Otherwise, fields whose pointers do not resolve to a value are left
+// unchanged. Other options are reported with an error matching
+// [ErrInvalidTagOption]. Bind sets every field that it can and returns the
+// errors from every field that could not be set joined together.
+func Bind(doc any, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return &unsupportedValueError{reflect.TypeOf(dst)}
+	}
+
+	v = v.Elem()
+
+	var errs []error
+	for _, f := range getBindFields(v.Type()) {
+		if f.err != nil {
+			errs = append(errs, &bindError{f.name, f.tag, f.err})
+			continue
+		}
+
+		field := v.Field(f.index)
+		value, err := f.ptr.Get(doc)
+		if err != nil {
+			if !errors.Is(err, ErrValueNotFound) && !errors.Is(err, ErrArrayIndexOutOfBounds) {
+				errs = append(errs, &bindError{f.name, f.tag, err})
+				continue
+			}
+
+			switch {
+			case f.hasDefault:
+				value = stringValue(field.Type(), f.def)
+			case f.required:
+				errs = append(errs, &bindError{f.name, f.tag, err})
+				continue
+			default:
+				continue
+			}
+		}
+
+		if err := bindValue(field, value); err != nil {
+			errs = append(errs, &bindError{f.name, f.tag, err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// bindValue sets the settable value v to value, converting between strings
+// and other types if value cannot be assigned by assign.
+func bindValue(v reflect.Value, value any) error {
+	err := assign(v, value)
+	if err == nil || !errors.Is(err, ErrTypeMismatch) {
+		return err
+	}
+
+	if s, ok := value.(string); ok {
+		if assign(v, stringValue(v.Type(), s)) == nil {
+			return nil
+		}
+
+		return err
+	}
+
+	data, jerr := json.Marshal(value)
+	if jerr != nil {
+		return err
+	}
+
+	if v.Kind() == reflect.String {
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+			return err
+		}
+
+		v.SetString(string(data))
+		return nil
+	}
+
+	if assign(v, encodedValue(data)) == nil {
+		return nil
+	}
+
+	return err
+}
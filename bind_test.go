@@ -0,0 +1,96 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	var doc any
+	err := json.Unmarshal([]byte(`{
+		"meta": {
+			"owner": {"id": 42, "name": "a"},
+			"tags": ["b", "c"],
+			"count": "7",
+			"ratio": 1.5
+		}
+	}`), &doc)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want <nil>", err)
+	}
+
+	type Owner struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	type Event struct {
+		OwnerID   int64    `jsonpointer:"/meta/owner/id,required"`
+		OwnerName string   `jsonpointer:"/meta/owner/name"`
+		Owner     Owner    `jsonpointer:"/meta/owner"`
+		FirstTag  string   `jsonpointer:"/meta/tags/0"`
+		Tags      []string `jsonpointer:"/meta/tags"`
+		Count     int      `jsonpointer:"/meta/count"`
+		Ratio     string   `jsonpointer:"/meta/ratio"`
+		Region    string   `jsonpointer:"/meta/region,default=eu,west"`
+		Retries   int      `jsonpointer:"/meta/retries,default=3"`
+		Missing   string   `jsonpointer:"/meta/missing"`
+		Untagged  string
+	}
+
+	e := Event{
+		Missing:  "x",
+		Untagged: "y",
+	}
+
+	if err := Bind(doc, &e); err != nil {
+		t.Fatalf("Bind() = %v, want <nil>", err)
+	}
+
+	want := Event{
+		OwnerID:   42,
+		OwnerName: "a",
+		Owner:     Owner{ID: 42, Name: "a"},
+		FirstTag:  "b",
+		Tags:      []string{"b", "c"},
+		Count:     7,
+		Ratio:     "1.5",
+		Region:    "eu,west",
+		Retries:   3,
+		Missing:   "x",
+		Untagged:  "y",
+	}
+
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("Bind() = %+v, want %+v", e, want)
+	}
+
+	type Invalid struct {
+		ID     int    `jsonpointer:"/meta/id,required"`
+		Name   int    `jsonpointer:"/meta/owner/name"`
+		Tag    string `jsonpointer:"/meta/tags/x"`
+		Option string `jsonpointer:"/meta/owner/name,optional"`
+		Ptr    string `jsonpointer:"meta"`
+	}
+
+	var invalid Invalid
+	err = Bind(doc, &invalid)
+	for _, target := range []error{ErrValueNotFound, ErrTypeMismatch, ErrInvalidArrayIndex, ErrInvalidTagOption, ErrInvalidPointer} {
+		if !errors.Is(err, target) {
+			t.Errorf("Bind() = %v, want %v", err, target)
+		}
+	}
+
+	if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 5 {
+		t.Errorf("Bind() returned %d errors, want 5", len(errs))
+	}
+
+	err = Bind(doc, invalid)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Bind() = %v, want %v", err, ErrUnsupportedValue)
+	}
+}
@@ -0,0 +1,280 @@
+package jsonpointer
+
+import (
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BundleOption configures how references are bundled by [Resolver.Bundle].
+type BundleOption func(*bundleOptions)
+
+type bundleOptions struct {
+	defs   Pointer
+	inline bool
+}
+
+// BundleDefinitions returns a [BundleOption] that sets the location in the
+// bundled document that referenced values are copied to. The default is
+// "/$defs".
+func BundleDefinitions(p Pointer) BundleOption {
+	return func(o *bundleOptions) {
+		o.defs = p
+	}
+}
+
+// InlineReferences returns a [BundleOption] that causes references to other
+// documents to be replaced by the referenced value itself, rather than by a
+// reference to a copy of it. References that are part of a cycle are still
+// replaced by references to a copy.
+func InlineReferences() BundleOption {
+	return func(o *bundleOptions) {
+		o.inline = true
+	}
+}
+
+// Bundle is like [Resolver.Bundle] using a new Resolver that loads documents
+// using loader.
+func Bundle(root any, base string, loader Loader, opts ...BundleOption) (any, error) {
+	return NewResolver(loader).Bundle(root, base, opts...)
+}
+
+// Bundle returns a copy of the document root, identified by the URI base, in
+// which every reference to another document has been replaced by a reference
+// to a copy of the referenced value held within the returned document, under
+// "/$defs" by default. References within the copied values are rewritten so
+// that they continue to refer to the same values. References within root to
+// root itself are left unchanged. root itself is not modified.
+func (r *Resolver) Bundle(root any, base string, opts ...BundleOption) (any, error) {
+	o := bundleOptions{
+		defs: Pointer{
+			tokens: []token{makeToken("$defs")},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	baseURI, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURI.Fragment = ""
+	baseURI.RawFragment = ""
+
+	recursive := make(map[string]bool)
+	for {
+		b := bundler{
+			r:         r,
+			opts:      o,
+			root:      baseURI.String(),
+			defs:      make(map[string]any),
+			names:     make(map[string]string),
+			recursive: recursive,
+		}
+
+		result, err := b.bundle(root, baseURI)
+		if err != nil {
+			return nil, err
+		}
+
+		if !b.restart {
+			return result, nil
+		}
+	}
+}
+
+type bundler struct {
+	r    *Resolver
+	opts bundleOptions
+	root string
+
+	defs  map[string]any
+	names map[string]string
+
+	// recursive holds the URIs of values that cannot be inlined because
+	// they are part of a reference cycle, and stack holds the URIs of the
+	// values currently being inlined.
+	recursive map[string]bool
+	stack     []string
+	restart   bool
+}
+
+func (b *bundler) bundle(root any, base *url.URL) (any, error) {
+	result, err := toGeneric(reflect.ValueOf(root))
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, err := b.opts.defs.Get(result); err == nil {
+		if m, ok := existing.(map[string]any); ok {
+			b.defs = m
+		}
+	}
+
+	result, err = b.walk(result, base)
+	if err != nil || len(b.names) == 0 {
+		return result, err
+	}
+
+	value := result
+	for i, tok := range b.opts.defs.tokens {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, &unsupportedValueError{reflect.TypeOf(value)}
+		}
+
+		if i == len(b.opts.defs.tokens)-1 {
+			m[tok.field] = b.defs
+			return result, nil
+		}
+
+		next, ok := m[tok.field]
+		if !ok {
+			next = make(map[string]any)
+			m[tok.field] = next
+		}
+
+		value = next
+	}
+
+	return nil, &unsupportedValueError{reflect.TypeOf(result)}
+}
+
+func (b *bundler) walk(value any, base *url.URL) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			return b.ref(ref, base)
+		}
+
+		for key, elem := range v {
+			elem, err := b.walk(elem, base)
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = elem
+		}
+	case []any:
+		for i, elem := range v {
+			elem, err := b.walk(elem, base)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = elem
+		}
+	}
+
+	return value, nil
+}
+
+func (b *bundler) ref(ref string, base *url.URL) (any, error) {
+	refURI, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := base.ResolveReference(refURI)
+	docURI := *uri
+	docURI.Fragment = ""
+	docURI.RawFragment = ""
+
+	if docURI.String() == b.root {
+		if base.String() == b.root {
+			return map[string]any{"$ref": ref}, nil
+		}
+
+		return map[string]any{"$ref": fragmentRef(uri.Fragment)}, nil
+	}
+
+	key := uri.String()
+	if b.opts.inline && !b.recursive[key] {
+		for _, k := range b.stack {
+			if k == key {
+				b.recursive[key] = true
+				b.restart = true
+				return nil, nil
+			}
+		}
+
+		b.stack = append(b.stack, key)
+		defer func() {
+			b.stack = b.stack[:len(b.stack)-1]
+		}()
+
+		return b.copy(uri, &docURI)
+	}
+
+	name, ok := b.names[key]
+	if !ok {
+		name = b.name(uri)
+		b.names[key] = name
+		b.defs[name] = nil
+
+		value, err := b.copy(uri, &docURI)
+		if err != nil {
+			return nil, err
+		}
+
+		b.defs[name] = value
+	}
+
+	return map[string]any{"$ref": fragmentRef(b.opts.defs.child(makeToken(name)).String())}, nil
+}
+
+// copy returns a copy of the value referred to by uri, with the references
+// within it bundled relative to the document docURI.
+func (b *bundler) copy(uri, docURI *url.URL) (any, error) {
+	value, err := b.r.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = toGeneric(reflect.ValueOf(value))
+	if err != nil {
+		return nil, err
+	}
+
+	return b.walk(value, docURI)
+}
+
+// name returns an unused name for the value referred to by uri, based on the
+// last reference token of its fragment, or the name of its document.
+func (b *bundler) name(uri *url.URL) string {
+	var name string
+	if p, err := Parse(uri.Fragment); err == nil && !p.IsZero() {
+		name = p.Token(p.NumTokens() - 1)
+	} else {
+		name = path.Base(uri.Path)
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	if _, ok := b.defs[name]; !ok {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		n := name + strconv.Itoa(i)
+		if _, ok := b.defs[n]; !ok {
+			return n
+		}
+	}
+}
+
+func fragmentRef(fragment string) string {
+	if fragment == "" {
+		return "#"
+	}
+
+	u := url.URL{
+		Fragment: fragment,
+	}
+
+	return u.String()
+}
@@ -0,0 +1,64 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundle(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"pets.json": {
+			Data: []byte(`{"Pet": {"properties": {"owner": {"$ref": "people.json"}, "friends": {"items": {"$ref": "#/Pet"}}}}}`),
+		},
+		"people.json": {
+			Data: []byte(`{"properties": {"name": {"$ref": "root.json#/$defs/Name"}}}`),
+		},
+	}
+
+	root := map[string]any{
+		"$defs": map[string]any{
+			"Name": map[string]any{"type": "string"},
+		},
+		"properties": map[string]any{
+			"pet":  map[string]any{"$ref": "pets.json#/Pet"},
+			"name": map[string]any{"$ref": "#/$defs/Name"},
+		},
+	}
+
+	result, err := Bundle(root, "file:///root.json", FSLoader(fsys))
+	if err != nil {
+		t.Fatalf("Bundle() = %v, want <nil>", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want <nil>", err)
+	}
+
+	want := `{"$defs":{"Name":{"type":"string"},"Pet":{"properties":{"friends":{"items":{"$ref":"#/$defs/Pet"}},"owner":{"$ref":"#/$defs/people"}}},"people":{"properties":{"name":{"$ref":"#/$defs/Name"}}}},"properties":{"name":{"$ref":"#/$defs/Name"},"pet":{"$ref":"#/$defs/Pet"}}}`
+	if string(data) != want {
+		t.Errorf("Bundle() = %s, want %s", data, want)
+	}
+
+	if _, ok := root["$defs"].(map[string]any)["Pet"]; ok {
+		t.Errorf("Bundle() modified its input")
+	}
+
+	result, err = Bundle(root, "file:///root.json", FSLoader(fsys), InlineReferences())
+	if err != nil {
+		t.Fatalf("Bundle() = %v, want <nil>", err)
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want <nil>", err)
+	}
+
+	want = `{"$defs":{"Name":{"type":"string"},"Pet":{"properties":{"friends":{"items":{"$ref":"#/$defs/Pet"}},"owner":{"properties":{"name":{"$ref":"#/$defs/Name"}}}}}},"properties":{"name":{"$ref":"#/$defs/Name"},"pet":{"$ref":"#/$defs/Pet"}}}`
+	if string(data) != want {
+		t.Errorf("Bundle() = %s, want %s", data, want)
+	}
+}
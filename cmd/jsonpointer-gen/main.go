@@ -0,0 +1,792 @@
+// Command jsonpointer-gen generates methods that resolve and set JSON pointers
+// against struct types without reflection.
+//
+// Usage:
+//
+//	jsonpointer-gen [-output file] [dir]
+//
+// jsonpointer-gen loads the Go package in dir, the current directory by
+// default, and generates GetPointer and SetPointer methods for every struct
+// type whose declaration is annotated with a comment of the form:
+//
+//	//jsonpointer:generate
+//
+// The generated methods implement the jsonpointer.Getter and
+// jsonpointer.Setter interfaces, so they are used by Pointer.Get and
+// Pointer.Set in place of reflection. Struct fields are named as they are by
+// Pointer.Get. The generated code follows pointers through nested structs,
+// slices, arrays and maps with string or integer keys itself, leaving only
+// values held in interfaces or json.RawMessage, map keys with UnmarshalText
+// methods and types that cannot be named in the package to the jsonpointer
+// package. The methods are written to the file jsonpointer_gen.go in dir,
+// unless another file is named by the -output flag.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const directive = "//jsonpointer:generate"
+
+func main() {
+	output := flag.String("output", "jsonpointer_gen.go", "name of the generated file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: jsonpointer-gen [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	dir := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		dir = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	out := *output
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(dir, out)
+	}
+
+	src, err := generate(dir, out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "jsonpointer-gen:", err)
+		os.Exit(1)
+	}
+
+	if src == nil {
+		return
+	}
+
+	if err := os.WriteFile(out, src, 0o666); err != nil {
+		fmt.Fprintln(os.Stderr, "jsonpointer-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// generate generates the methods for the annotated types of the package in
+// dir, ignoring the previously generated file out. generate returns nil if
+// there are no annotated types.
+func generate(dir, out string) ([]byte, error) {
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	absOut, err := filepath.Abs(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, name := range matches {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if abs, err := filepath.Abs(name); err == nil && abs == absOut {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, f)
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if hasDirective(ts.Doc) || (len(gen.Specs) == 1 && hasDirective(gen.Doc)) {
+					names = append(names, ts.Name.Name)
+				}
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	g := generator{
+		pkg:     pkg,
+		imports: make(map[string]string),
+	}
+
+	for _, name := range names {
+		obj := pkg.Scope().Lookup(name)
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a named type", name)
+		}
+
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("%s is not a struct type", name)
+		}
+
+		g.generate(named)
+	}
+
+	g.generateHelpers()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by jsonpointer-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	std := []string{"fmt"}
+	other := []string{"github.com/woodsbury/jsonpointer"}
+	for path := range g.imports {
+		elem, _, _ := strings.Cut(path, "/")
+		if strings.Contains(elem, ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+
+	fmt.Fprintf(&buf, "import (\n")
+	for i, paths := range [][]string{std, other} {
+		if i > 0 {
+			fmt.Fprintf(&buf, "\n")
+		}
+
+		slices.Sort(paths)
+		for _, path := range slices.Compact(paths) {
+			if name := g.imports[path]; name != "" && name != pathpkg.Base(path) {
+				fmt.Fprintf(&buf, "\t%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&buf, "\t%q\n", path)
+			}
+		}
+	}
+
+	fmt.Fprintf(&buf, ")\n")
+	buf.Write(g.buf.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+func hasDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == directive {
+			return true
+		}
+	}
+
+	return false
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string
+	helpers []types.Type
+	buf     bytes.Buffer
+}
+
+// field describes a struct field that a reference token refers to, possibly
+// promoted through embedded structs.
+type field struct {
+	name string
+	path []*types.Var
+}
+
+// How the reference tokens that continue past a value are resolved.
+const (
+	noTokens      = iota // the value has no members or elements
+	typedTokens          // by generated helper functions
+	reflectTokens        // by the jsonpointer package
+)
+
+func (g *generator) generate(named *types.Named) {
+	name := named.Obj().Name()
+	h := g.helper(named)
+
+	g.printf("\n// GetPointer implements the [jsonpointer.Getter] interface.\n")
+	g.printf("func (x *%s) GetPointer(p jsonpointer.Pointer) (any, error) {\n", name)
+	g.printf("if p.IsZero() {\nreturn x, nil\n}\n\n")
+	g.printf("if x == nil {\nreturn nil, %s\n}\n\n", notFound("0"))
+	g.printf("return jsonpointerGet%d(x, p, 0)\n", h)
+	g.printf("}\n")
+
+	g.printf("\n// SetPointer implements the [jsonpointer.Setter] interface.\n")
+	g.printf("func (x *%s) SetPointer(p jsonpointer.Pointer, value any) error {\n", name)
+	g.printf("if x == nil {\nreturn fmt.Errorf(\"%%w nil *%s\", jsonpointer.ErrUnsupportedValue)\n}\n\n", name)
+	g.printf("if p.IsZero() {\n")
+	g.printf("switch v := value.(type) {\ncase %s:\n*x = v\nreturn nil\ncase *%s:\nif v != nil {\n*x = *v\nreturn nil\n}\n}\n\n", name, name)
+	g.printf("return fmt.Errorf(\"%%w: cannot use %%T as %s\", jsonpointer.ErrTypeMismatch, value)\n}\n\n", name)
+	g.printf("return jsonpointerSet%d(x, p, 0, value)\n", h)
+	g.printf("}\n")
+}
+
+// helper returns the number of the helper functions that resolve reference
+// tokens against values of type t, adding them if they do not exist yet.
+func (g *generator) helper(t types.Type) int {
+	t = types.Unalias(t)
+	for i, h := range g.helpers {
+		if types.Identical(h, t) {
+			return i
+		}
+	}
+
+	g.helpers = append(g.helpers, t)
+	return len(g.helpers) - 1
+}
+
+// generateHelpers prints the helper functions, including those added while
+// printing others.
+func (g *generator) generateHelpers() {
+	for i := 0; i < len(g.helpers); i++ {
+		t := g.helpers[i]
+		ptr := g.typeString(types.NewPointer(t))
+
+		g.printf("\n// jsonpointerGet%d resolves the reference tokens of p from the index i\n", i)
+		g.printf("// against *x.\n")
+		g.printf("func jsonpointerGet%d(x %s, p jsonpointer.Pointer, i int) (any, error) {\n", i, ptr)
+		switch u := t.Underlying().(type) {
+		case *types.Struct:
+			g.getStruct(t)
+		case *types.Slice:
+			g.getIndex(u.Elem())
+		case *types.Array:
+			g.getIndex(u.Elem())
+		case *types.Map:
+			g.getMap(u)
+		}
+
+		g.printf("}\n")
+
+		g.printf("\n// jsonpointerSet%d sets the value referred to by the reference tokens of p\n", i)
+		g.printf("// from the index i within *x to value.\n")
+		g.printf("func jsonpointerSet%d(x %s, p jsonpointer.Pointer, i int, value any) error {\n", i, ptr)
+		switch u := t.Underlying().(type) {
+		case *types.Struct:
+			g.setStruct(t)
+		case *types.Slice:
+			g.setIndex(u.Elem(), true)
+		case *types.Array:
+			g.setIndex(u.Elem(), false)
+		case *types.Map:
+			g.setMap(u)
+		}
+
+		g.printf("}\n")
+	}
+}
+
+func (g *generator) getStruct(t types.Type) {
+	if fields := structFields(t); len(fields) > 0 {
+		g.printf("switch p.Token(i) {\n")
+		for _, f := range fields {
+			expr := g.fieldCase(f)
+			g.printf("if i+1 == p.NumTokens() {\nreturn %s, nil\n}\n\n", expr)
+			g.getNext("&"+expr, f.path[len(f.path)-1].Type())
+		}
+
+		g.printf("}\n\n")
+	}
+
+	g.printf("return nil, %s\n", notFound("i"))
+}
+
+func (g *generator) setStruct(t types.Type) {
+	if fields := structFields(t); len(fields) > 0 {
+		g.printf("switch p.Token(i) {\n")
+		for _, f := range fields {
+			expr := g.fieldCase(f)
+			ft := f.path[len(f.path)-1].Type()
+			g.printf("if i+1 == p.NumTokens() {\n")
+			g.setLeaf("&"+expr, ft, "")
+			g.printf("}\n\n")
+			g.setNext("&"+expr, ft, "")
+		}
+
+		g.printf("}\n\n")
+	}
+
+	g.printf("return %s\n", notFound("i"))
+}
+
+// checkIndex prints the checks of the array index n against *x, returning
+// with ret followed by an error if it is not within bounds.
+func (g *generator) checkIndex(ret string) {
+	g.printf("if n == -1 {\n")
+	g.printf("if p.Token(i) == \"-\" {\n%sfmt.Errorf(\"%%w %%d\", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))\n}\n\n", ret)
+	g.printf("%sfmt.Errorf(\"%%w %%q\", jsonpointer.ErrInvalidArrayIndex, p.Token(i))\n", ret)
+	g.printf("}\n\n")
+	g.printf("if n >= len(*x) {\n%sfmt.Errorf(\"%%w %%d\", jsonpointer.ErrArrayIndexOutOfBounds, n)\n}\n\n", ret)
+}
+
+func (g *generator) getIndex(elem types.Type) {
+	g.printf("n := p.Index(i)\n")
+	g.checkIndex("return nil, ")
+	g.printf("if i+1 == p.NumTokens() {\nreturn (*x)[n], nil\n}\n\n")
+	g.getNext("&(*x)[n]", elem)
+}
+
+func (g *generator) setIndex(elem types.Type, appendable bool) {
+	g.printf("n := p.Index(i)\n")
+	if appendable {
+		g.printf("if i+1 == p.NumTokens() && (n == len(*x) || p.Token(i) == \"-\") {\n")
+		g.printf("var elem %s\n", g.typeString(elem))
+		g.setLeaf("&elem", elem, "*x = append(*x, elem)")
+		g.printf("}\n\n")
+	}
+
+	g.checkIndex("return ")
+	g.printf("if i+1 == p.NumTokens() {\n")
+	g.setLeaf("&(*x)[n]", elem, "")
+	g.printf("}\n\n")
+	g.setNext("&(*x)[n]", elem, "")
+}
+
+// mapKey prints the conversion of the reference token at the index i into
+// the map key key, returning with ret followed by an error if it cannot be
+// converted.
+func (g *generator) mapKey(t types.Type, ret string) {
+	b := t.Underlying().(*types.Basic)
+	if b.Info()&types.IsString != 0 {
+		g.printf("key := %s\n", g.convert(t, "p.Token(i)", types.String))
+		return
+	}
+
+	g.imports["strconv"] = "strconv"
+
+	var bits int
+	switch b.Kind() {
+	case types.Int8, types.Uint8:
+		bits = 8
+	case types.Int16, types.Uint16:
+		bits = 16
+	case types.Int32, types.Uint32:
+		bits = 32
+	case types.Int64, types.Uint64:
+		bits = 64
+	}
+
+	kind := types.Int64
+	parse := "ParseInt"
+	if b.Info()&types.IsUnsigned != 0 {
+		kind = types.Uint64
+		parse = "ParseUint"
+	}
+
+	g.printf("n, err := strconv.%s(p.Token(i), 10, %d)\n", parse, bits)
+	g.printf("if err != nil {\n%s%s\n}\n\n", ret, notFound("i"))
+	g.printf("key := %s\n", g.convert(t, "n", kind))
+}
+
+// convert returns the expression that converts expr, of the basic type kind,
+// to t.
+func (g *generator) convert(t types.Type, expr string, kind types.BasicKind) string {
+	if types.Identical(t, types.Typ[kind]) {
+		return expr
+	}
+
+	return g.typeString(t) + "(" + expr + ")"
+}
+
+func (g *generator) getMap(t *types.Map) {
+	g.mapKey(t.Key(), "return nil, ")
+	g.printf("elem, ok := (*x)[key]\n")
+	g.printf("if !ok {\nreturn nil, %s\n}\n\n", notFound("i"))
+	g.printf("if i+1 == p.NumTokens() {\nreturn elem, nil\n}\n\n")
+	g.getNext("&elem", t.Elem())
+}
+
+func (g *generator) setMap(t *types.Map) {
+	g.printf("if *x == nil {\nreturn %s\n}\n\n", notFound("i"))
+	g.mapKey(t.Key(), "return ")
+	g.printf("elem, ok := (*x)[key]\n")
+	g.printf("if i+1 == p.NumTokens() {\n")
+	g.setLeaf("&elem", t.Elem(), "(*x)[key] = elem")
+	g.printf("}\n\n")
+	g.printf("if !ok {\nreturn %s\n}\n\n", notFound("i"))
+	g.setNext("&elem", t.Elem(), "(*x)[key] = elem")
+}
+
+// getNext prints the resolution of the reference tokens from the index i+1
+// against the value of type t that addr points to.
+func (g *generator) getNext(addr string, t types.Type) {
+	addr, t = g.derefPointers(addr, t, "return nil, ")
+	switch g.tokens(t) {
+	case typedTokens:
+		g.printf("return jsonpointerGet%d(%s, p, i+1)\n", g.helper(t), addr)
+	case reflectTokens:
+		g.printf("return p.Trim(i+1).Get(%s)\n", deref(addr))
+	default:
+		g.printf("return nil, %s\n", notFound("i+1"))
+	}
+}
+
+// setNext prints the setting of the value referred to by the reference
+// tokens from the index i+1 within the value of type t that addr points to,
+// followed by commit if it succeeds.
+func (g *generator) setNext(addr string, t types.Type, commit string) {
+	addr, t = g.derefPointers(addr, t, "return ")
+
+	var call string
+	switch g.tokens(t) {
+	case typedTokens:
+		call = fmt.Sprintf("jsonpointerSet%d(%s, p, i+1, value)", g.helper(t), addr)
+	case reflectTokens:
+		call = fmt.Sprintf("p.Trim(i+1).Set(%s, value)", addr)
+	default:
+		g.printf("return %s\n", notFound("i+1"))
+		return
+	}
+
+	g.finish(call, commit)
+}
+
+// setLeaf prints the setting of the value of type t that addr points to,
+// followed by commit if it succeeds. Values that are not of type t are
+// converted by the jsonpointer package.
+func (g *generator) setLeaf(addr string, t types.Type, commit string) {
+	if g.nameable(t) {
+		g.printf("if v, ok := value.(%s); ok {\n%s = v\n", g.typeString(t), deref(addr))
+		if commit != "" {
+			g.printf("%s\n", commit)
+		}
+
+		g.printf("return nil\n}\n\n")
+	}
+
+	g.finish(fmt.Sprintf("(jsonpointer.Pointer{}).Set(%s, value)", addr), commit)
+}
+
+// finish prints a return of the error from call, running commit first if
+// there is no error.
+func (g *generator) finish(call, commit string) {
+	if commit == "" {
+		g.printf("return %s\n", call)
+		return
+	}
+
+	g.printf("if err := %s; err != nil {\nreturn err\n}\n\n", call)
+	g.printf("%s\nreturn nil\n", commit)
+}
+
+// derefPointers prints checks that the pointers that addr points to, through
+// any number of levels, are not nil, returning with ret followed by an error
+// if they are. derefPointers returns the address and type of the value that
+// is finally pointed to.
+func (g *generator) derefPointers(addr string, t types.Type, ret string) (string, types.Type) {
+	for {
+		p, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			return addr, t
+		}
+
+		addr = deref(addr)
+		g.printf("if %s == nil {\n%s%s\n}\n\n", addr, ret, notFound("i+1"))
+		t = p.Elem()
+	}
+}
+
+// tokens reports how the reference tokens that continue past a value of type
+// t are resolved.
+func (g *generator) tokens(t types.Type) int {
+	if isRawMessage(t) || !g.nameable(t) {
+		return reflectTokens
+	}
+
+	t = types.Unalias(t)
+
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		return reflectTokens
+	case *types.Struct:
+		fields := structFields(t)
+		if len(fields) == 0 {
+			return noTokens
+		}
+
+		// Fields promoted through unexported embedded fields of other
+		// packages cannot be referred to by their full paths.
+		for _, f := range fields {
+			for _, v := range f.path {
+				if !v.Exported() && v.Pkg() != g.pkg {
+					return reflectTokens
+				}
+			}
+		}
+
+		return typedTokens
+	case *types.Slice, *types.Array:
+		return typedTokens
+	case *types.Map:
+		if !supportedKey(u.Key()) {
+			return reflectTokens
+		}
+
+		return typedTokens
+	default:
+		return noTokens
+	}
+}
+
+// supportedKey reports whether map keys of type t can be converted from
+// reference tokens by generated code, rather than by their UnmarshalText
+// methods.
+func supportedKey(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok || b.Info()&(types.IsString|types.IsInteger) == 0 {
+		return false
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, nil, "UnmarshalText")
+	return obj == nil
+}
+
+// isRawMessage reports whether t is json.RawMessage, which may be declared as
+// an alias.
+func isRawMessage(t types.Type) bool {
+	var obj *types.TypeName
+	switch t := t.(type) {
+	case *types.Alias:
+		if isRawMessage(t.Rhs()) {
+			return true
+		}
+
+		obj = t.Obj()
+	case *types.Named:
+		obj = t.Obj()
+	default:
+		return false
+	}
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "encoding/json" && obj.Name() == "RawMessage"
+}
+
+// deref returns the expression for the value that addr points to.
+func deref(addr string) string {
+	if strings.HasPrefix(addr, "&") {
+		return addr[1:]
+	}
+
+	return "*" + addr
+}
+
+// notFound returns the expression for the error reported when the reference
+// token at the index i is not found.
+func notFound(i string) string {
+	return fmt.Sprintf("fmt.Errorf(\"%%w %%q\", jsonpointer.ErrValueNotFound, p.Token(%s))", i)
+}
+
+// fieldCase prints the case clause for f, including checks for nil embedded
+// struct pointers, and returns the expression that refers to the field.
+func (g *generator) fieldCase(f field) string {
+	g.printf("case %s:\n", strconv.Quote(f.name))
+
+	expr := "x"
+	for i, v := range f.path {
+		expr += "." + v.Name()
+		if i == len(f.path)-1 {
+			break
+		}
+
+		if _, ok := v.Type().(*types.Pointer); ok {
+			g.printf("if %s == nil {\nbreak\n}\n\n", expr)
+		}
+	}
+
+	return expr
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// nameable reports whether t can be written in the generated file.
+func (g *generator) nameable(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Kind() != types.UnsafePointer
+	case *types.Named:
+		if t.TypeArgs().Len() > 0 {
+			return false
+		}
+
+		obj := t.Obj()
+		return obj.Pkg() == nil || obj.Pkg() == g.pkg || obj.Exported()
+	case *types.Alias:
+		return g.nameable(types.Unalias(t))
+	case *types.Pointer:
+		return g.nameable(t.Elem())
+	case *types.Slice:
+		return g.nameable(t.Elem())
+	case *types.Array:
+		return g.nameable(t.Elem())
+	case *types.Map:
+		return g.nameable(t.Key()) && g.nameable(t.Elem())
+	case *types.Interface:
+		return t.Empty()
+	case *types.Struct:
+		for v := range t.Fields() {
+			if !v.Exported() && v.Pkg() != g.pkg || !g.nameable(v.Type()) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+
+		g.imports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	})
+}
+
+// structFields returns the fields of the struct type t, named in the same way
+// that the jsonpointer package names them, sorted by name.
+func structFields(t types.Type) []field {
+	type level struct {
+		t    types.Type
+		path []*types.Var
+	}
+
+	fields := make(map[string]field)
+
+	var current []level
+	next := []level{{
+		t: t,
+	}}
+
+	var visited []types.Type
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+
+		for _, l := range current {
+			if slices.ContainsFunc(visited, func(v types.Type) bool {
+				return types.Identical(v, l.t)
+			}) {
+				continue
+			}
+
+			visited = append(visited, l.t)
+
+			st := l.t.Underlying().(*types.Struct)
+			for i := range st.NumFields() {
+				v := st.Field(i)
+				ft := v.Type()
+				if p, ok := ft.(*types.Pointer); ok {
+					ft = p.Elem()
+				}
+
+				_, isStruct := ft.Underlying().(*types.Struct)
+				if v.Embedded() {
+					if !v.Exported() && !isStruct {
+						continue
+					}
+				} else if !v.Exported() {
+					continue
+				}
+
+				name := v.Name()
+				tag := reflect.StructTag(st.Tag(i)).Get("json")
+				if tag != "" {
+					if tag == "-" {
+						continue
+					}
+
+					tag, _, _ = strings.Cut(tag, ",")
+					for _, r := range tag {
+						if strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r) {
+							continue
+						}
+
+						if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+							tag = ""
+							break
+						}
+					}
+
+					if tag != "" {
+						name = tag
+					}
+				}
+
+				path := append(slices.Clip(l.path), v)
+				if !v.Embedded() || !isStruct {
+					fields[name] = field{
+						name: name,
+						path: path,
+					}
+
+					continue
+				}
+
+				next = append(next, level{
+					t:    ft,
+					path: path,
+				})
+			}
+		}
+	}
+
+	result := make([]field, 0, len(fields))
+	for _, f := range fields {
+		result = append(result, f)
+	}
+
+	slices.SortFunc(result, func(a, b field) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return result
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/woodsbury/jsonpointer"
+	"github.com/woodsbury/jsonpointer/cmd/jsonpointer-gen/testdata/example"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("testdata", "example")
+	src, err := generate(dir, filepath.Join(dir, "jsonpointer_gen.go"))
+	if err != nil {
+		t.Fatalf("generate() = %v, want <nil>", err)
+	}
+
+	if !strings.HasPrefix(string(src), "// Code generated by jsonpointer-gen. DO NOT EDIT.\n") {
+		t.Errorf("generate() is missing the generated code header")
+	}
+
+	for _, want := range []string{
+		"func (x *Config) GetPointer(p jsonpointer.Pointer) (any, error)",
+		"func (x *Config) SetPointer(p jsonpointer.Pointer, value any) error",
+		"func (x *Database) GetPointer(p jsonpointer.Pointer) (any, error)",
+		`case "owner":`,
+		"if x.Meta == nil {",
+		"value.(time.Duration)",
+		`case "Host":`,
+		"return jsonpointerGet1(&x.DB, p, i+1)",
+		"func jsonpointerGet2(x *[]string, p jsonpointer.Pointer, i int) (any, error)",
+		"strconv.ParseInt(p.Token(i), 10, 0)",
+		"key := Zone(p.Token(i))",
+		"return p.Trim(i + 1).Get(x.Raw)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generate() does not contain %q", want)
+		}
+	}
+
+	for _, unwanted := range []string{
+		"Ignored)",
+		`case "Skipped":`,
+		`case "-":`,
+		`case "private":`,
+		`case "Meta":`,
+	} {
+		if strings.Contains(string(src), unwanted) {
+			t.Errorf("generate() contains %q", unwanted)
+		}
+	}
+
+	fset := token.NewFileSet()
+	example, err := parser.ParseFile(fset, filepath.Join(dir, "example.go"), nil, 0)
+	if err != nil {
+		t.Fatalf("parser.ParseFile() = %v, want <nil>", err)
+	}
+
+	generated, err := parser.ParseFile(fset, "jsonpointer_gen.go", src, 0)
+	if err != nil {
+		t.Fatalf("parser.ParseFile() = %v, want <nil>", err)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+
+	_, err = conf.Check("example", fset, []*ast.File{example, generated}, nil)
+	if err != nil {
+		t.Errorf("types.Config.Check() = %v, want <nil>", err)
+	}
+
+	checkedIn, err := os.ReadFile(filepath.Join(dir, "jsonpointer_gen.go"))
+	if err != nil {
+		t.Fatalf("os.ReadFile() = %v, want <nil>", err)
+	}
+
+	if string(checkedIn) != string(src) {
+		t.Errorf("testdata/example/jsonpointer_gen.go is out of date, run jsonpointer-gen testdata/example")
+	}
+}
+
+func newConfig() example.Config {
+	return example.Config{
+		Name:    "a",
+		Port:    80,
+		Hosts:   []string{"b", "c"},
+		Labels:  map[string]string{"a": "d"},
+		Timeout: time.Second,
+		Started: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		DB: example.Database{
+			Host: "e",
+			Port: 5432,
+		},
+		Replicas: map[int]*example.Database{
+			1: {Host: "f", Port: 1},
+			2: nil,
+		},
+		Zones: map[example.Zone][]int{
+			"eu": {1, 2},
+		},
+		Ports: [2]uint16{8080, 8443},
+		Extra: map[string]any{
+			"a": []any{"g"},
+		},
+		Raw: json.RawMessage(`{"c": "h"}`),
+		Meta: &example.Meta{
+			Owner: "i",
+		},
+	}
+}
+
+var errOther = errors.New("other error")
+
+// errorKind returns the error that err matches among those the generated
+// methods and Pointer.Get and Pointer.Set can return.
+func errorKind(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, target := range []error{
+		jsonpointer.ErrArrayIndexOutOfBounds,
+		jsonpointer.ErrInvalidArrayIndex,
+		jsonpointer.ErrTypeMismatch,
+		jsonpointer.ErrValueNotFound,
+	} {
+		if errors.Is(err, target) {
+			return target
+		}
+	}
+
+	return errOther
+}
+
+func TestGeneratedMethods(t *testing.T) {
+	t.Parallel()
+
+	// Pointer.Get and Pointer.Set use reflection rather than the generated
+	// methods when the config is an element of a slice.
+	configs := []func() example.Config{
+		newConfig,
+		func() example.Config {
+			return example.Config{}
+		},
+	}
+
+	pointers := []string{
+		"/name",
+		"/name/x",
+		"/port",
+		"/hosts",
+		"/hosts/1",
+		"/hosts/2",
+		"/hosts/-",
+		"/hosts/x",
+		"/hosts/01",
+		"/hosts/0/x",
+		"/labels/a",
+		"/labels/b",
+		"/timeout",
+		"/started",
+		"/started/x",
+		"/db",
+		"/db/port",
+		"/db/Host",
+		"/db/x",
+		"/replicas/1",
+		"/replicas/1/port",
+		"/replicas/2/port",
+		"/replicas/3",
+		"/replicas/x",
+		"/zones/eu/1",
+		"/zones/us",
+		"/ports/1",
+		"/ports/2",
+		"/ports/-",
+		"/extra/a/0",
+		"/extra/b",
+		"/raw/c",
+		"/raw/d",
+		"/owner",
+		"/inner/A",
+		"/inner/B",
+		"/missing",
+	}
+
+	for _, config := range configs {
+		for _, ptr := range pointers {
+			cfg := config()
+			got, gotErr := jsonpointer.MustParse(ptr).Get(&cfg)
+			want, wantErr := jsonpointer.MustParse("/0" + ptr).Get([]example.Config{config()})
+			if !reflect.DeepEqual(got, want) || errorKind(gotErr) != errorKind(wantErr) {
+				t.Errorf("Config.GetPointer(%s) = (%v, %v), want (%v, %v)", ptr, got, gotErr, want, wantErr)
+			}
+		}
+	}
+
+	type test struct {
+		ptr   string
+		value any
+	}
+
+	tests := []test{
+		{"/name", "b"},
+		{"/name", 1},
+		{"/name/x", "b"},
+		{"/port", 8080.0},
+		{"/port", 0.5},
+		{"/port", json.Number("81")},
+		{"/hosts/0", "z"},
+		{"/hosts/-", "y"},
+		{"/hosts/2", "x"},
+		{"/hosts/5", "w"},
+		{"/hosts/x", "v"},
+		{"/hosts/-/x", "u"},
+		{"/labels/c", "d"},
+		{"/labels/a/b", "c"},
+		{"/timeout", 5},
+		{"/started", time.Time{}},
+		{"/db", example.Database{Host: "h"}},
+		{"/db/port", 5},
+		{"/db/x", 5},
+		{"/replicas/1/port", 3},
+		{"/replicas/2/port", 3},
+		{"/replicas/4", &example.Database{Port: 4}},
+		{"/replicas/5/port", 5},
+		{"/replicas/x", nil},
+		{"/zones/eu/-", 3},
+		{"/zones/eu/0", 4.0},
+		{"/zones/ap/0", 1},
+		{"/zones/ap", []int{1}},
+		{"/ports/0", 9},
+		{"/ports/0", -1},
+		{"/ports/-", 9},
+		{"/extra/a/0", "j"},
+		{"/extra/b", 1},
+		{"/raw/c", "k"},
+		{"/owner", "l"},
+		{"/inner/A", 2},
+		{"/inner/B", 2},
+		{"/missing", 1},
+	}
+
+	for _, config := range configs {
+		for _, test := range tests {
+			got := config()
+			gotErr := jsonpointer.MustParse(test.ptr).Set(&got, test.value)
+
+			want := []example.Config{config()}
+			wantErr := jsonpointer.MustParse("/0"+test.ptr).Set(want, test.value)
+			if errorKind(gotErr) != errorKind(wantErr) {
+				t.Errorf("Config.SetPointer(%s, %v) = %v, want %v", test.ptr, test.value, gotErr, wantErr)
+			}
+
+			if !reflect.DeepEqual(got, want[0]) {
+				t.Errorf("Config.SetPointer(%s, %v) set %+v, want %+v", test.ptr, test.value, got, want[0])
+			}
+		}
+	}
+}
+
+func TestGenerateNone(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src, err := generate(".", filepath.Join(dir, "jsonpointer_gen.go"))
+	if src != nil || err != nil {
+		t.Errorf("generate() = (%s, %v), want (<nil>, <nil>)", src, err)
+	}
+}
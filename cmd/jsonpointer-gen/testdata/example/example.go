@@ -0,0 +1,44 @@
+package example
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//jsonpointer:generate
+type Config struct {
+	Name     string            `json:"name"`
+	Port     int               `json:"port,omitempty"`
+	Hosts    []string          `json:"hosts"`
+	Labels   map[string]string `json:"labels"`
+	Timeout  time.Duration     `json:"timeout"`
+	Started  time.Time         `json:"started"`
+	DB       Database          `json:"db"`
+	Replicas map[int]*Database `json:"replicas"`
+	Zones    map[Zone][]int    `json:"zones"`
+	Ports    [2]uint16         `json:"ports"`
+	Extra    any               `json:"extra"`
+	Raw      json.RawMessage   `json:"raw"`
+	Skipped  string            `json:"-"`
+	*Meta
+	private int
+}
+
+//jsonpointer:generate
+type Database struct {
+	Host string
+	Port int `json:"port"`
+}
+
+type Meta struct {
+	Owner string `json:"owner"`
+	Inner struct {
+		A int
+	} `json:"inner"`
+}
+
+type Zone string
+
+type Ignored struct {
+	A int
+}
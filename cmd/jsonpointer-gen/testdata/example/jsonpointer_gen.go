@@ -0,0 +1,799 @@
+// Code generated by jsonpointer-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/woodsbury/jsonpointer"
+)
+
+// GetPointer implements the [jsonpointer.Getter] interface.
+func (x *Config) GetPointer(p jsonpointer.Pointer) (any, error) {
+	if p.IsZero() {
+		return x, nil
+	}
+
+	if x == nil {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(0))
+	}
+
+	return jsonpointerGet0(x, p, 0)
+}
+
+// SetPointer implements the [jsonpointer.Setter] interface.
+func (x *Config) SetPointer(p jsonpointer.Pointer, value any) error {
+	if x == nil {
+		return fmt.Errorf("%w nil *Config", jsonpointer.ErrUnsupportedValue)
+	}
+
+	if p.IsZero() {
+		switch v := value.(type) {
+		case Config:
+			*x = v
+			return nil
+		case *Config:
+			if v != nil {
+				*x = *v
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: cannot use %T as Config", jsonpointer.ErrTypeMismatch, value)
+	}
+
+	return jsonpointerSet0(x, p, 0, value)
+}
+
+// GetPointer implements the [jsonpointer.Getter] interface.
+func (x *Database) GetPointer(p jsonpointer.Pointer) (any, error) {
+	if p.IsZero() {
+		return x, nil
+	}
+
+	if x == nil {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(0))
+	}
+
+	return jsonpointerGet1(x, p, 0)
+}
+
+// SetPointer implements the [jsonpointer.Setter] interface.
+func (x *Database) SetPointer(p jsonpointer.Pointer, value any) error {
+	if x == nil {
+		return fmt.Errorf("%w nil *Database", jsonpointer.ErrUnsupportedValue)
+	}
+
+	if p.IsZero() {
+		switch v := value.(type) {
+		case Database:
+			*x = v
+			return nil
+		case *Database:
+			if v != nil {
+				*x = *v
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: cannot use %T as Database", jsonpointer.ErrTypeMismatch, value)
+	}
+
+	return jsonpointerSet1(x, p, 0, value)
+}
+
+// jsonpointerGet0 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet0(x *Config, p jsonpointer.Pointer, i int) (any, error) {
+	switch p.Token(i) {
+	case "db":
+		if i+1 == p.NumTokens() {
+			return x.DB, nil
+		}
+
+		return jsonpointerGet1(&x.DB, p, i+1)
+	case "extra":
+		if i+1 == p.NumTokens() {
+			return x.Extra, nil
+		}
+
+		return p.Trim(i + 1).Get(x.Extra)
+	case "hosts":
+		if i+1 == p.NumTokens() {
+			return x.Hosts, nil
+		}
+
+		return jsonpointerGet2(&x.Hosts, p, i+1)
+	case "inner":
+		if x.Meta == nil {
+			break
+		}
+
+		if i+1 == p.NumTokens() {
+			return x.Meta.Inner, nil
+		}
+
+		return jsonpointerGet3(&x.Meta.Inner, p, i+1)
+	case "labels":
+		if i+1 == p.NumTokens() {
+			return x.Labels, nil
+		}
+
+		return jsonpointerGet4(&x.Labels, p, i+1)
+	case "name":
+		if i+1 == p.NumTokens() {
+			return x.Name, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "owner":
+		if x.Meta == nil {
+			break
+		}
+
+		if i+1 == p.NumTokens() {
+			return x.Meta.Owner, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "port":
+		if i+1 == p.NumTokens() {
+			return x.Port, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "ports":
+		if i+1 == p.NumTokens() {
+			return x.Ports, nil
+		}
+
+		return jsonpointerGet5(&x.Ports, p, i+1)
+	case "raw":
+		if i+1 == p.NumTokens() {
+			return x.Raw, nil
+		}
+
+		return p.Trim(i + 1).Get(x.Raw)
+	case "replicas":
+		if i+1 == p.NumTokens() {
+			return x.Replicas, nil
+		}
+
+		return jsonpointerGet6(&x.Replicas, p, i+1)
+	case "started":
+		if i+1 == p.NumTokens() {
+			return x.Started, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "timeout":
+		if i+1 == p.NumTokens() {
+			return x.Timeout, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "zones":
+		if i+1 == p.NumTokens() {
+			return x.Zones, nil
+		}
+
+		return jsonpointerGet7(&x.Zones, p, i+1)
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+}
+
+// jsonpointerSet0 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet0(x *Config, p jsonpointer.Pointer, i int, value any) error {
+	switch p.Token(i) {
+	case "db":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(Database); ok {
+				x.DB = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.DB, value)
+		}
+
+		return jsonpointerSet1(&x.DB, p, i+1, value)
+	case "extra":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(any); ok {
+				x.Extra = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Extra, value)
+		}
+
+		return p.Trim(i+1).Set(&x.Extra, value)
+	case "hosts":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.([]string); ok {
+				x.Hosts = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Hosts, value)
+		}
+
+		return jsonpointerSet2(&x.Hosts, p, i+1, value)
+	case "inner":
+		if x.Meta == nil {
+			break
+		}
+
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(struct{ A int }); ok {
+				x.Meta.Inner = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Meta.Inner, value)
+		}
+
+		return jsonpointerSet3(&x.Meta.Inner, p, i+1, value)
+	case "labels":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(map[string]string); ok {
+				x.Labels = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Labels, value)
+		}
+
+		return jsonpointerSet4(&x.Labels, p, i+1, value)
+	case "name":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(string); ok {
+				x.Name = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Name, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "owner":
+		if x.Meta == nil {
+			break
+		}
+
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(string); ok {
+				x.Meta.Owner = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Meta.Owner, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "port":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(int); ok {
+				x.Port = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Port, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "ports":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.([2]uint16); ok {
+				x.Ports = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Ports, value)
+		}
+
+		return jsonpointerSet5(&x.Ports, p, i+1, value)
+	case "raw":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(json.RawMessage); ok {
+				x.Raw = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Raw, value)
+		}
+
+		return p.Trim(i+1).Set(&x.Raw, value)
+	case "replicas":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(map[int]*Database); ok {
+				x.Replicas = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Replicas, value)
+		}
+
+		return jsonpointerSet6(&x.Replicas, p, i+1, value)
+	case "started":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(time.Time); ok {
+				x.Started = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Started, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "timeout":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(time.Duration); ok {
+				x.Timeout = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Timeout, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "zones":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(map[Zone][]int); ok {
+				x.Zones = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Zones, value)
+		}
+
+		return jsonpointerSet7(&x.Zones, p, i+1, value)
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+}
+
+// jsonpointerGet1 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet1(x *Database, p jsonpointer.Pointer, i int) (any, error) {
+	switch p.Token(i) {
+	case "Host":
+		if i+1 == p.NumTokens() {
+			return x.Host, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "port":
+		if i+1 == p.NumTokens() {
+			return x.Port, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+}
+
+// jsonpointerSet1 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet1(x *Database, p jsonpointer.Pointer, i int, value any) error {
+	switch p.Token(i) {
+	case "Host":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(string); ok {
+				x.Host = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Host, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	case "port":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(int); ok {
+				x.Port = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.Port, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+}
+
+// jsonpointerGet2 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet2(x *[]string, p jsonpointer.Pointer, i int) (any, error) {
+	n := p.Index(i)
+	if n == -1 {
+		if p.Token(i) == "-" {
+			return nil, fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrInvalidArrayIndex, p.Token(i))
+	}
+
+	if n >= len(*x) {
+		return nil, fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, n)
+	}
+
+	if i+1 == p.NumTokens() {
+		return (*x)[n], nil
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerSet2 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet2(x *[]string, p jsonpointer.Pointer, i int, value any) error {
+	n := p.Index(i)
+	if i+1 == p.NumTokens() && (n == len(*x) || p.Token(i) == "-") {
+		var elem string
+		if v, ok := value.(string); ok {
+			elem = v
+			*x = append(*x, elem)
+			return nil
+		}
+
+		if err := (jsonpointer.Pointer{}).Set(&elem, value); err != nil {
+			return err
+		}
+
+		*x = append(*x, elem)
+		return nil
+	}
+
+	if n == -1 {
+		if p.Token(i) == "-" {
+			return fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrInvalidArrayIndex, p.Token(i))
+	}
+
+	if n >= len(*x) {
+		return fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, n)
+	}
+
+	if i+1 == p.NumTokens() {
+		if v, ok := value.(string); ok {
+			(*x)[n] = v
+			return nil
+		}
+
+		return (jsonpointer.Pointer{}).Set(&(*x)[n], value)
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerGet3 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet3(x *struct{ A int }, p jsonpointer.Pointer, i int) (any, error) {
+	switch p.Token(i) {
+	case "A":
+		if i+1 == p.NumTokens() {
+			return x.A, nil
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+}
+
+// jsonpointerSet3 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet3(x *struct{ A int }, p jsonpointer.Pointer, i int, value any) error {
+	switch p.Token(i) {
+	case "A":
+		if i+1 == p.NumTokens() {
+			if v, ok := value.(int); ok {
+				x.A = v
+				return nil
+			}
+
+			return (jsonpointer.Pointer{}).Set(&x.A, value)
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+}
+
+// jsonpointerGet4 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet4(x *map[string]string, p jsonpointer.Pointer, i int) (any, error) {
+	key := p.Token(i)
+	elem, ok := (*x)[key]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	if i+1 == p.NumTokens() {
+		return elem, nil
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerSet4 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet4(x *map[string]string, p jsonpointer.Pointer, i int, value any) error {
+	if *x == nil {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	key := p.Token(i)
+	elem, ok := (*x)[key]
+	if i+1 == p.NumTokens() {
+		if v, ok := value.(string); ok {
+			elem = v
+			(*x)[key] = elem
+			return nil
+		}
+
+		if err := (jsonpointer.Pointer{}).Set(&elem, value); err != nil {
+			return err
+		}
+
+		(*x)[key] = elem
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerGet5 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet5(x *[2]uint16, p jsonpointer.Pointer, i int) (any, error) {
+	n := p.Index(i)
+	if n == -1 {
+		if p.Token(i) == "-" {
+			return nil, fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrInvalidArrayIndex, p.Token(i))
+	}
+
+	if n >= len(*x) {
+		return nil, fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, n)
+	}
+
+	if i+1 == p.NumTokens() {
+		return (*x)[n], nil
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerSet5 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet5(x *[2]uint16, p jsonpointer.Pointer, i int, value any) error {
+	n := p.Index(i)
+	if n == -1 {
+		if p.Token(i) == "-" {
+			return fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrInvalidArrayIndex, p.Token(i))
+	}
+
+	if n >= len(*x) {
+		return fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, n)
+	}
+
+	if i+1 == p.NumTokens() {
+		if v, ok := value.(uint16); ok {
+			(*x)[n] = v
+			return nil
+		}
+
+		return (jsonpointer.Pointer{}).Set(&(*x)[n], value)
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerGet6 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet6(x *map[int]*Database, p jsonpointer.Pointer, i int) (any, error) {
+	n, err := strconv.ParseInt(p.Token(i), 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	key := int(n)
+	elem, ok := (*x)[key]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	if i+1 == p.NumTokens() {
+		return elem, nil
+	}
+
+	if elem == nil {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	}
+
+	return jsonpointerGet1(elem, p, i+1)
+}
+
+// jsonpointerSet6 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet6(x *map[int]*Database, p jsonpointer.Pointer, i int, value any) error {
+	if *x == nil {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	n, err := strconv.ParseInt(p.Token(i), 10, 0)
+	if err != nil {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	key := int(n)
+	elem, ok := (*x)[key]
+	if i+1 == p.NumTokens() {
+		if v, ok := value.(*Database); ok {
+			elem = v
+			(*x)[key] = elem
+			return nil
+		}
+
+		if err := (jsonpointer.Pointer{}).Set(&elem, value); err != nil {
+			return err
+		}
+
+		(*x)[key] = elem
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	if elem == nil {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+	}
+
+	if err := jsonpointerSet1(elem, p, i+1, value); err != nil {
+		return err
+	}
+
+	(*x)[key] = elem
+	return nil
+}
+
+// jsonpointerGet7 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet7(x *map[Zone][]int, p jsonpointer.Pointer, i int) (any, error) {
+	key := Zone(p.Token(i))
+	elem, ok := (*x)[key]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	if i+1 == p.NumTokens() {
+		return elem, nil
+	}
+
+	return jsonpointerGet8(&elem, p, i+1)
+}
+
+// jsonpointerSet7 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet7(x *map[Zone][]int, p jsonpointer.Pointer, i int, value any) error {
+	if *x == nil {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	key := Zone(p.Token(i))
+	elem, ok := (*x)[key]
+	if i+1 == p.NumTokens() {
+		if v, ok := value.([]int); ok {
+			elem = v
+			(*x)[key] = elem
+			return nil
+		}
+
+		if err := (jsonpointer.Pointer{}).Set(&elem, value); err != nil {
+			return err
+		}
+
+		(*x)[key] = elem
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i))
+	}
+
+	if err := jsonpointerSet8(&elem, p, i+1, value); err != nil {
+		return err
+	}
+
+	(*x)[key] = elem
+	return nil
+}
+
+// jsonpointerGet8 resolves the reference tokens of p from the index i
+// against *x.
+func jsonpointerGet8(x *[]int, p jsonpointer.Pointer, i int) (any, error) {
+	n := p.Index(i)
+	if n == -1 {
+		if p.Token(i) == "-" {
+			return nil, fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))
+		}
+
+		return nil, fmt.Errorf("%w %q", jsonpointer.ErrInvalidArrayIndex, p.Token(i))
+	}
+
+	if n >= len(*x) {
+		return nil, fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, n)
+	}
+
+	if i+1 == p.NumTokens() {
+		return (*x)[n], nil
+	}
+
+	return nil, fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
+
+// jsonpointerSet8 sets the value referred to by the reference tokens of p
+// from the index i within *x to value.
+func jsonpointerSet8(x *[]int, p jsonpointer.Pointer, i int, value any) error {
+	n := p.Index(i)
+	if i+1 == p.NumTokens() && (n == len(*x) || p.Token(i) == "-") {
+		var elem int
+		if v, ok := value.(int); ok {
+			elem = v
+			*x = append(*x, elem)
+			return nil
+		}
+
+		if err := (jsonpointer.Pointer{}).Set(&elem, value); err != nil {
+			return err
+		}
+
+		*x = append(*x, elem)
+		return nil
+	}
+
+	if n == -1 {
+		if p.Token(i) == "-" {
+			return fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, len(*x))
+		}
+
+		return fmt.Errorf("%w %q", jsonpointer.ErrInvalidArrayIndex, p.Token(i))
+	}
+
+	if n >= len(*x) {
+		return fmt.Errorf("%w %d", jsonpointer.ErrArrayIndexOutOfBounds, n)
+	}
+
+	if i+1 == p.NumTokens() {
+		if v, ok := value.(int); ok {
+			(*x)[n] = v
+			return nil
+		}
+
+		return (jsonpointer.Pointer{}).Set(&(*x)[n], value)
+	}
+
+	return fmt.Errorf("%w %q", jsonpointer.ErrValueNotFound, p.Token(i+1))
+}
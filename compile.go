@@ -0,0 +1,159 @@
+package jsonpointer
+
+import (
+	"reflect"
+)
+
+// Accessor is a JSON pointer compiled against a Go type by [Compile]. An
+// Accessor resolves its pointer using only the struct field indices, array
+// indices and map keys computed when it was compiled, rather than looking up
+// struct fields by name each time.
+type Accessor struct {
+	t      reflect.Type
+	result reflect.Type
+	steps  []accessStep
+}
+
+type accessStepKind uint8
+
+const (
+	accessField accessStepKind = iota
+	accessIndex
+	accessMapKey
+	accessDynamic
+)
+
+type accessStep struct {
+	kind accessStepKind
+
+	// derefs is the number of pointers that are dereferenced before the
+	// step is performed.
+	derefs int
+
+	tok   token
+	field []int
+	key   reflect.Value
+
+	// rest holds the remainder of the pointer for an accessDynamic step,
+	// which is resolved against the value held in an interface or
+	// json.RawMessage.
+	rest Pointer
+}
+
+// Compile compiles the JSON pointer p for resolving against values of type
+// t. Compile returns an error if p can never resolve against a value of type
+// t, as reported by [Pointer.CheckType]. Reference tokens following an
+// interface or [json.RawMessage] value are resolved as they are by
+// [Pointer.Get]. Compile returns an error matching [ErrUnsupportedValue] if t
+// is nil.
+func Compile(p Pointer, t reflect.Type) (Accessor, error) {
+	if t == nil {
+		return Accessor{}, &unsupportedValueError{t}
+	}
+
+	a := Accessor{
+		t: t,
+	}
+
+	cur := t
+	for i, tok := range p.tokens {
+		var step accessStep
+		for cur.Kind() == reflect.Pointer {
+			step.derefs++
+			cur = cur.Elem()
+		}
+
+		if cur.Kind() == reflect.Interface || cur == rawMessageType {
+			step.kind = accessDynamic
+			step.rest = Pointer{
+				tokens: p.tokens[i:],
+			}
+
+			a.steps = append(a.steps, step)
+			a.result = cur
+			return a, nil
+		}
+
+		next, err := typeStep(cur, tok)
+		if err != nil {
+			return Accessor{}, err
+		}
+
+		step.tok = tok
+		switch cur.Kind() {
+		case reflect.Struct:
+			step.kind = accessField
+			step.field = getStructFields(cur)[tok.field]
+		case reflect.Array, reflect.Slice:
+			step.kind = accessIndex
+		case reflect.Map:
+			step.kind = accessMapKey
+			step.key, _ = mapKey(cur.Key(), tok.field)
+		}
+
+		a.steps = append(a.steps, step)
+		cur = next
+	}
+
+	a.result = cur
+	return a, nil
+}
+
+// Get resolves the compiled JSON pointer against value, which must be of the
+// type the Accessor was compiled for, and returns the result.
+func (a Accessor) Get(value any) (any, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.Type() != a.t {
+		return nil, &unsupportedValueError{reflect.TypeOf(value)}
+	}
+
+	for _, step := range a.steps {
+		for range step.derefs {
+			if v.IsNil() {
+				return nil, &valueNotFoundError{step.tok.field}
+			}
+
+			v = v.Elem()
+		}
+
+		switch step.kind {
+		case accessField:
+			if len(step.field) == 1 {
+				v = v.Field(step.field[0])
+				break
+			}
+
+			var err error
+			v, err = v.FieldByIndexErr(step.field)
+			if err != nil {
+				return nil, &valueNotFoundError{step.tok.field}
+			}
+		case accessIndex:
+			if step.tok.index >= v.Len() {
+				return nil, &arrayIndexOutOfBoundsError{step.tok.index}
+			}
+
+			v = v.Index(step.tok.index)
+		case accessMapKey:
+			v = v.MapIndex(step.key)
+			if !v.IsValid() {
+				return nil, &valueNotFoundError{step.tok.field}
+			}
+		case accessDynamic:
+			if v.Kind() == reflect.Interface && v.IsNil() {
+				return nil, &valueNotFoundError{step.rest.tokens[0].field}
+			}
+
+			return step.rest.Get(v.Interface())
+		}
+	}
+
+	return v.Interface(), nil
+}
+
+// Type returns the type of the values that the Accessor resolves to. If the
+// pointer continues past an interface or [json.RawMessage] value, Type
+// returns the type of that value.
+func (a Accessor) Type() reflect.Type {
+	return a.result
+}
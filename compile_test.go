@@ -0,0 +1,143 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	type Options struct {
+		Timeout int `json:"timeout"`
+	}
+
+	type Host struct {
+		*Options
+		Port int `json:"port"`
+	}
+
+	type Config struct {
+		Hosts []*Host         `json:"hosts"`
+		ByID  map[int]Host    `json:"by_id"`
+		Extra any             `json:"extra"`
+		Raw   json.RawMessage `json:"raw"`
+	}
+
+	value := &Config{
+		Hosts: []*Host{
+			{Port: 1},
+			{Options: &Options{Timeout: 2}, Port: 3},
+			nil,
+		},
+		ByID: map[int]Host{
+			4: {Port: 5},
+		},
+		Extra: map[string]any{"a": "b"},
+		Raw:   json.RawMessage(`{"c": "d"}`),
+	}
+
+	type test struct {
+		ptr  string
+		want any
+		err  error
+	}
+
+	tests := []test{
+		{"/hosts/0/port", 1, nil},
+		{"/hosts/1/timeout", 2, nil},
+		{"/hosts/0/timeout", nil, ErrValueNotFound},
+		{"/hosts/2/port", nil, ErrValueNotFound},
+		{"/hosts/3/port", nil, ErrArrayIndexOutOfBounds},
+		{"/by_id/4/port", 5, nil},
+		{"/by_id/6/port", nil, ErrValueNotFound},
+		{"/extra/a", "b", nil},
+		{"/raw/c", "d", nil},
+	}
+
+	for _, test := range tests {
+		a, err := Compile(MustParse(test.ptr), reflect.TypeOf(value))
+		if err != nil {
+			t.Fatalf("Compile(%s) = %v, want <nil>", test.ptr, err)
+		}
+
+		result, err := a.Get(value)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("Accessor(%s).Get() = %v, want %v", test.ptr, err, test.err)
+			}
+
+			continue
+		}
+
+		if result != test.want || err != nil {
+			t.Errorf("Accessor(%s).Get() = (%v, %v), want (%v, <nil>)", test.ptr, result, err, test.want)
+		}
+	}
+
+	_, err := Compile(MustParse("/missing"), reflect.TypeOf(value))
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Compile(/missing) = %v, want %v", err, ErrValueNotFound)
+	}
+
+	a, err := Compile(MustParse("/hosts"), reflect.TypeOf(value))
+	if err != nil {
+		t.Fatalf("Compile(/hosts) = %v, want <nil>", err)
+	}
+
+	if a.Type() != reflect.TypeFor[[]*Host]() {
+		t.Errorf("Accessor.Type() = %v, want %v", a.Type(), reflect.TypeFor[[]*Host]())
+	}
+
+	_, err = a.Get(*value)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Accessor.Get() = %v, want %v", err, ErrUnsupportedValue)
+	}
+
+	_, err = Compile(MustParse("/hosts"), reflect.TypeOf(nil))
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Compile(nil) = %v, want %v", err, ErrUnsupportedValue)
+	}
+}
+
+func BenchmarkAccessorGetStruct(b *testing.B) {
+	b.ReportAllocs()
+
+	type C struct {
+		C string
+	}
+
+	type B struct {
+		B C
+	}
+
+	type A struct {
+		A []B
+	}
+
+	value := &A{
+		A: []B{
+			{},
+			{},
+			{
+				B: C{
+					C: "D",
+				},
+			},
+		},
+	}
+
+	a, err := Compile(MustParse("/A/2/B/C"), reflect.TypeOf(value))
+	if err != nil {
+		b.Fatalf("Compile(/A/2/B/C) = %v, want <nil>", err)
+	}
+
+	for b.Loop() {
+		_, err = a.Get(value)
+		if err != nil {
+			b.Fatalf("Accessor.Get() = %v, want <nil>", err)
+		}
+	}
+}
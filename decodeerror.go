@@ -0,0 +1,71 @@
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrorPointer returns the JSON pointer of the location in the encoded JSON
+// data at which err occurred, where err is an error returned by
+// [json.Unmarshal] or a [json.Decoder] when decoding data. For a
+// [*json.UnmarshalTypeError], this is the pointer of the value that could not
+// be decoded. For a [*json.SyntaxError], this is the pointer of the value
+// being read when the error occurred, or of the value most recently read.
+// ErrorPointer returns false if err is not one of these errors.
+//
+// If data is nil, the pointer of a [*json.UnmarshalTypeError] is instead
+// derived from its Field, which is ambiguous if any member names contain a
+// period. A [*json.SyntaxError] carries no such information, so ErrorPointer
+// returns false for one if data is nil.
+func ErrorPointer(data []byte, err error) (Pointer, bool) {
+	var offset int64
+
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		if data == nil {
+			return fieldPointer(typeErr.Field), true
+		}
+
+		offset = typeErr.Offset
+	case errors.As(err, &syntaxErr):
+		if data == nil {
+			return Pointer{}, false
+		}
+
+		offset = syntaxErr.Offset
+	default:
+		return Pointer{}, false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	t := NewPathTracker(dec)
+	for dec.InputOffset() < offset {
+		if _, err := t.Token(); err != nil {
+			break
+		}
+	}
+
+	return t.Pointer(), true
+}
+
+// fieldPointer converts the dotted path of a json.UnmarshalTypeError Field
+// into a Pointer.
+func fieldPointer(field string) Pointer {
+	if field == "" {
+		return Pointer{}
+	}
+
+	fields := strings.Split(field, ".")
+	tokens := make([]token, len(fields))
+	for i, f := range fields {
+		tokens[i] = makeToken(f)
+	}
+
+	return Pointer{
+		tokens: tokens,
+	}
+}
@@ -0,0 +1,61 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestErrorPointer(t *testing.T) {
+	t.Parallel()
+
+	type B struct {
+		Name int `json:"name"`
+	}
+
+	type A struct {
+		Items []B          `json:"items"`
+		M     map[string]B `json:"m"`
+	}
+
+	type test struct {
+		data string
+		want string
+	}
+
+	tests := []test{
+		{`{"items": [{}, {"name": "x"}]}`, "/items/1/name"},
+		{`{"m": {"a.b": {"name": {"c": 1}}}}`, "/m/a.b/name"},
+		{`{"m": {"a.b": {"name": [1, 2]}}}`, "/m/a.b/name"},
+		{`{"items": [{"name": 1}, {"name": tru}]}`, "/items/1/name"},
+		{`{"items": [{"name": 1}, {"name": 2}}`, "/items/1"},
+	}
+
+	for _, test := range tests {
+		var v A
+		err := json.Unmarshal([]byte(test.data), &v)
+		if err == nil {
+			t.Fatalf("json.Unmarshal(%s) = <nil>, want error", test.data)
+		}
+
+		p, ok := ErrorPointer([]byte(test.data), err)
+		if !ok || p.String() != test.want {
+			t.Errorf("ErrorPointer(%s, %v) = (%s, %t), want (%s, true)", test.data, err, p, ok, test.want)
+		}
+	}
+
+	var v A
+	err := json.Unmarshal([]byte(`{"items": [{"name": "x"}]}`), &v)
+	p, ok := ErrorPointer(nil, err)
+	if !ok || p.String() != "/items/0/name" {
+		t.Errorf("ErrorPointer(nil, %v) = (%s, %t), want (/items/0/name, true)", err, p, ok)
+	}
+
+	err = json.Unmarshal([]byte(`{"items": [}`), &v)
+	if _, ok := ErrorPointer(nil, err); ok {
+		t.Errorf("ErrorPointer(nil, %v) = true, want false", err)
+	}
+
+	if _, ok := ErrorPointer(nil, ErrValueNotFound); ok {
+		t.Errorf("ErrorPointer() = true, want false")
+	}
+}
@@ -0,0 +1,341 @@
+package jsonpointer
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// DiffOption configures how documents are compared by [Diff].
+type DiffOption func(*diffOptions)
+
+type diffOptions struct {
+	lcs    bool
+	moves  bool
+	copies bool
+}
+
+// DiffArraysLCS returns a [DiffOption] that causes arrays to be compared by
+// finding their longest common subsequence of elements, so that insertions
+// and removals in the middle of an array produce a single operation. By
+// default arrays are compared index by index.
+func DiffArraysLCS() DiffOption {
+	return func(o *diffOptions) {
+		o.lcs = true
+	}
+}
+
+// DiffCopies returns a [DiffOption] that causes added values that are equal
+// to an unchanged value elsewhere in the document to be produced as "copy"
+// operations.
+func DiffCopies() DiffOption {
+	return func(o *diffOptions) {
+		o.copies = true
+	}
+}
+
+// DiffMoves returns a [DiffOption] that causes a value removed from one object
+// member and added to another to be produced as a single "move" operation.
+func DiffMoves() DiffOption {
+	return func(o *diffOptions) {
+		o.moves = true
+	}
+}
+
+// Diff compares the documents a and b and returns a [Patch] of "add",
+// "remove" and "replace" operations that transforms a into b. Values are
+// compared as they are by [EqualValues]. Object members are compared in order
+// of their names, so the same documents always produce the same patch.
+func Diff(a, b any, opts ...DiffOption) (Patch, error) {
+	var o diffOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ga, err := toGeneric(reflect.ValueOf(a))
+	if err != nil {
+		return nil, err
+	}
+
+	gb, err := toGeneric(reflect.ValueOf(b))
+	if err != nil {
+		return nil, err
+	}
+
+	d := differ{
+		opts: o,
+	}
+
+	d.diff(Pointer{}, ga, gb, false)
+
+	if o.moves {
+		d.detectMoves()
+	}
+
+	if o.copies {
+		d.detectCopies(ga, gb)
+	}
+
+	patch := make(Patch, len(d.ops))
+	for i, op := range d.ops {
+		patch[i] = op.Operation
+	}
+
+	return patch, nil
+}
+
+type differ struct {
+	opts diffOptions
+	ops  []diffOperation
+}
+
+type diffOperation struct {
+	Operation
+	old any
+
+	// inArray is set if the path of the operation passes through an array.
+	inArray bool
+}
+
+func (d *differ) add(path Pointer, value any, inArray bool) {
+	d.ops = append(d.ops, diffOperation{
+		Operation: Operation{
+			Op:    "add",
+			Path:  path,
+			Value: value,
+		},
+		inArray: inArray,
+	})
+}
+
+func (d *differ) remove(path Pointer, old any, inArray bool) {
+	d.ops = append(d.ops, diffOperation{
+		Operation: Operation{
+			Op:   "remove",
+			Path: path,
+		},
+		old:     old,
+		inArray: inArray,
+	})
+}
+
+func (d *differ) replace(path Pointer, value any) {
+	d.ops = append(d.ops, diffOperation{
+		Operation: Operation{
+			Op:    "replace",
+			Path:  path,
+			Value: value,
+		},
+	})
+}
+
+func (d *differ) diff(path Pointer, a, b any, inArray bool) {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			d.diffObjects(path, a, b, inArray)
+			return
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			if d.opts.lcs {
+				d.diffArraysLCS(path, a, b)
+			} else {
+				d.diffArrays(path, a, b)
+			}
+
+			return
+		}
+	default:
+		if equalGeneric(a, b) {
+			return
+		}
+	}
+
+	d.replace(path, b)
+}
+
+func (d *differ) diffObjects(path Pointer, a, b map[string]any, inArray bool) {
+	for _, key := range slices.Sorted(maps.Keys(a)) {
+		if _, ok := b[key]; !ok {
+			d.remove(path.child(makeToken(key)), a[key], inArray)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(b)) {
+		va, ok := a[key]
+		if !ok {
+			d.add(path.child(makeToken(key)), b[key], inArray)
+			continue
+		}
+
+		d.diff(path.child(makeToken(key)), va, b[key], inArray)
+	}
+}
+
+func (d *differ) diffArrays(path Pointer, a, b []any) {
+	n := min(len(a), len(b))
+	for i := range n {
+		d.diff(path.child(indexToken(i)), a[i], b[i], true)
+	}
+
+	for i := len(a) - 1; i >= n; i-- {
+		d.remove(path.child(indexToken(i)), a[i], true)
+	}
+
+	for i := n; i < len(b); i++ {
+		d.add(path.child(indexToken(i)), b[i], true)
+	}
+}
+
+func (d *differ) diffArraysLCS(path Pointer, a, b []any) {
+	// lengths[i][j] holds the length of the longest common subsequence of
+	// a[i:] and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if equalGeneric(a[i], b[j]) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	// k tracks the index in the array as modified by the operations
+	// produced so far.
+	var i, j, k int
+	for i < len(a) && j < len(b) {
+		switch {
+		case equalGeneric(a[i], b[j]):
+			i++
+			j++
+			k++
+		case lengths[i+1][j] == lengths[i][j] && lengths[i][j+1] == lengths[i][j]:
+			d.diff(path.child(indexToken(k)), a[i], b[j], true)
+			i++
+			j++
+			k++
+		case lengths[i+1][j] == lengths[i][j]:
+			d.remove(path.child(indexToken(k)), a[i], true)
+			i++
+		default:
+			d.add(path.child(indexToken(k)), b[j], true)
+			j++
+			k++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		d.remove(path.child(indexToken(k)), a[i], true)
+	}
+
+	for ; j < len(b); j++ {
+		d.add(path.child(indexToken(k)), b[j], true)
+		k++
+	}
+}
+
+// detectMoves combines the removal of a value from an object member with the
+// addition of an equal value to another object member into a single "move"
+// operation. Operations within arrays are left alone, as the indices they
+// refer to depend on the operations around them.
+func (d *differ) detectMoves() {
+	for i := 0; i < len(d.ops); i++ {
+		rm := d.ops[i]
+		if rm.Op != "remove" || rm.inArray {
+			continue
+		}
+
+		for j := range d.ops {
+			add := &d.ops[j]
+			if add.Op != "add" || add.inArray || !equalGeneric(add.Value, rm.old) {
+				continue
+			}
+
+			if rm.Path.isPrefixOf(add.Path) || add.Path.isPrefixOf(rm.Path) {
+				continue
+			}
+
+			add.Op = "move"
+			add.From = rm.Path
+			add.Value = nil
+			d.ops = slices.Delete(d.ops, i, i+1)
+			i--
+			break
+		}
+	}
+}
+
+// detectCopies replaces the addition of an object or array that is equal to a
+// value that is unchanged between a and b with a "copy" operation.
+func (d *differ) detectCopies(a, b any) {
+	for i := range d.ops {
+		op := &d.ops[i]
+		if op.Op != "add" || isScalar(op.Value) {
+			continue
+		}
+
+		from, ok := findUnchanged(Pointer{}, a, b, op.Value)
+		if !ok {
+			continue
+		}
+
+		op.Op = "copy"
+		op.From = from
+		op.Value = nil
+	}
+}
+
+// findUnchanged searches a for a value equal to value that is at the same
+// location in b, following only object members.
+func findUnchanged(path Pointer, a, b, value any) (Pointer, bool) {
+	oa, ok := a.(map[string]any)
+	if !ok {
+		return Pointer{}, false
+	}
+
+	ob, ok := b.(map[string]any)
+	if !ok {
+		return Pointer{}, false
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(oa)) {
+		vb, ok := ob[key]
+		if !ok {
+			continue
+		}
+
+		va := oa[key]
+		if equalGeneric(va, value) && equalGeneric(vb, value) {
+			return path.child(makeToken(key)), true
+		}
+
+		if p, ok := findUnchanged(path.child(makeToken(key)), va, vb, value); ok {
+			return p, true
+		}
+	}
+
+	return Pointer{}, false
+}
+
+func isScalar(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return false
+	default:
+		return true
+	}
+}
+
+func indexToken(i int) token {
+	return token{
+		field: strconv.Itoa(i),
+		index: i,
+	}
+}
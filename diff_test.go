@@ -0,0 +1,113 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		a, b any
+		opts []DiffOption
+		want string
+	}
+
+	tests := []test{
+		{
+			a:    map[string]any{"a": "b"},
+			b:    map[string]any{"a": "b"},
+			want: `[]`,
+		},
+		{
+			a:    map[string]any{"a": "b", "c": "d"},
+			b:    map[string]any{"a": "e", "f": nil},
+			want: `[{"op":"remove","path":"/c"},{"op":"replace","path":"/a","value":"e"},{"op":"add","path":"/f","value":null}]`,
+		},
+		{
+			a:    map[string]any{"a/b": map[string]any{"c~d": 1.0}},
+			b:    map[string]any{"a/b": map[string]any{"c~d": 2.0}},
+			want: `[{"op":"replace","path":"/a~1b/c~0d","value":2}]`,
+		},
+		{
+			a:    []any{"a", "b", "c"},
+			b:    []any{"a", "x"},
+			want: `[{"op":"replace","path":"/1","value":"x"},{"op":"remove","path":"/2"}]`,
+		},
+		{
+			a:    []any{"a", "b", "c"},
+			b:    []any{"b", "c", "d"},
+			opts: []DiffOption{DiffArraysLCS()},
+			want: `[{"op":"remove","path":"/0"},{"op":"add","path":"/2","value":"d"}]`,
+		},
+		{
+			a:    []any{"a", "b", "c"},
+			b:    []any{"a", "x", "c"},
+			opts: []DiffOption{DiffArraysLCS()},
+			want: `[{"op":"replace","path":"/1","value":"x"}]`,
+		},
+		{
+			a:    map[string]any{"a": map[string]any{"b": "c"}},
+			b:    map[string]any{"d": map[string]any{"b": "c"}},
+			opts: []DiffOption{DiffMoves()},
+			want: `[{"op":"move","from":"/a","path":"/d"}]`,
+		},
+		{
+			a:    map[string]any{"a": map[string]any{"b": "c"}},
+			b:    map[string]any{"a": map[string]any{"b": "c"}, "d": map[string]any{"b": "c"}},
+			opts: []DiffOption{DiffCopies()},
+			want: `[{"op":"copy","from":"/a","path":"/d"}]`,
+		},
+		{
+			a:    "a",
+			b:    []any{"a"},
+			want: `[{"op":"replace","path":"","value":["a"]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		patch, err := Diff(test.a, test.b, test.opts...)
+		if err != nil {
+			t.Fatalf("Diff(%v, %v) = %v, want <nil>", test.a, test.b, err)
+		}
+
+		data, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatalf("json.Marshal() = %v, want <nil>", err)
+		}
+
+		if string(data) != test.want {
+			t.Errorf("Diff(%v, %v) = %s, want %s", test.a, test.b, data, test.want)
+		}
+	}
+
+	type A struct {
+		A string `json:"a"`
+		B []int  `json:"b"`
+	}
+
+	patch, err := Diff(&A{A: "x", B: []int{1}}, &A{A: "y", B: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("Diff() = %v, want <nil>", err)
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want <nil>", err)
+	}
+
+	want := `[{"op":"replace","path":"/a","value":"y"},{"op":"add","path":"/b/1","value":2}]`
+	if string(data) != want {
+		t.Errorf("Diff() = %s, want %s", data, want)
+	}
+
+	var decoded Patch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want <nil>", err)
+	}
+
+	if len(decoded) != 2 || decoded[1].Op != "add" || decoded[1].Path.String() != "/b/1" || decoded[1].Value != 2.0 {
+		t.Errorf("json.Unmarshal() = %+v, want %s", decoded, want)
+	}
+}
@@ -0,0 +1,102 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Document is a JSON document, held as a generic tree of map[string]any and
+// []any values, that can be read and modified by JSON pointer from multiple
+// goroutines. The zero value is a document holding null.
+//
+// Modifications copy the objects and arrays along the locations they modify
+// rather than modifying them in place, and replace the whole tree at once.
+// Readers therefore never wait for writers, never observe a partially applied
+// [Patch], and values returned by [Document.Get] and [Document.Value] are not
+// changed by later modifications. Those values are shared with the document
+// and must not be modified.
+//
+// A Document must not be copied after first use.
+type Document struct {
+	mu   sync.Mutex
+	root atomic.Pointer[any]
+}
+
+// NewDocument returns a Document holding value, which is converted to a
+// generic tree. The Document does not share any objects or arrays with value.
+func NewDocument(value any) (*Document, error) {
+	root, err := toGeneric(reflect.ValueOf(value))
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Document{}
+	d.root.Store(&root)
+	return d, nil
+}
+
+// Apply applies patch to the document, as [Patch.Apply] does. If any
+// operation fails, the document is left unchanged.
+func (d *Document) Apply(patch Patch) error {
+	return d.update(patch.apply)
+}
+
+// Delete removes the value referred to by the JSON pointer p from the
+// document, as the "remove" operation of RFC 6902 does.
+func (d *Document) Delete(p Pointer) error {
+	return d.update(func(root any) (any, error) {
+		return update(root, p.tokens, updateRemove, nil)
+	})
+}
+
+// Get resolves the JSON pointer p against the document and returns the
+// result.
+func (d *Document) Get(p Pointer, opts ...GetOption) (any, error) {
+	return p.Get(d.Value(), opts...)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Value())
+}
+
+// Set sets the value referred to by the JSON pointer p within the document to
+// value, which is converted to a generic tree, as [Pointer.Set] does.
+func (d *Document) Set(p Pointer, value any) error {
+	v, err := toGeneric(reflect.ValueOf(value))
+	if err != nil {
+		return err
+	}
+
+	return d.update(func(root any) (any, error) {
+		return update(root, p.tokens, updateSet, v)
+	})
+}
+
+// Value returns the document's current generic tree.
+func (d *Document) Value() any {
+	root := d.root.Load()
+	if root == nil {
+		return nil
+	}
+
+	return *root
+}
+
+// update replaces the document's tree with the result of calling f with the
+// current tree, unless f returns an error. Calls to update are serialised so
+// that no modification is lost.
+func (d *Document) update(f func(root any) (any, error)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	root, err := f(d.Value())
+	if err != nil {
+		return err
+	}
+
+	d.root.Store(&root)
+	return nil
+}
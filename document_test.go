@@ -0,0 +1,119 @@
+package jsonpointer
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Hosts []string `json:"hosts"`
+		Port  int      `json:"port"`
+	}
+
+	d, err := NewDocument(Config{Hosts: []string{"a"}, Port: 1})
+	if err != nil {
+		t.Fatalf("NewDocument() = %v, want <nil>", err)
+	}
+
+	result, err := d.Get(MustParse("/hosts/0"))
+	if result != "a" || err != nil {
+		t.Fatalf("Document.Get() = (%v, %v), want (a, <nil>)", result, err)
+	}
+
+	hosts, err := d.Get(MustParse("/hosts"))
+	if err != nil {
+		t.Fatalf("Document.Get() = %v, want <nil>", err)
+	}
+
+	if err := d.Set(MustParse("/hosts/0"), "b"); err != nil {
+		t.Fatalf("Document.Set() = %v, want <nil>", err)
+	}
+
+	if err := d.Set(MustParse("/hosts/-"), "c"); err != nil {
+		t.Fatalf("Document.Set() = %v, want <nil>", err)
+	}
+
+	if !EqualValues(hosts, []any{"a"}) {
+		t.Errorf("Document.Set() modified a previous result to %v", hosts)
+	}
+
+	if err := d.Delete(MustParse("/port")); err != nil {
+		t.Fatalf("Document.Delete() = %v, want <nil>", err)
+	}
+
+	err = d.Delete(MustParse("/port"))
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Document.Delete() = %v, want %v", err, ErrValueNotFound)
+	}
+
+	err = d.Apply(Patch{
+		{Op: "add", Path: MustParse("/port"), Value: 2},
+		{Op: "test", Path: MustParse("/hosts/0"), Value: "x"},
+	})
+	if !errors.Is(err, ErrTestFailed) {
+		t.Errorf("Document.Apply() = %v, want %v", err, ErrTestFailed)
+	}
+
+	data, err := d.MarshalJSON()
+	if string(data) != `{"hosts":["b","c"]}` || err != nil {
+		t.Errorf("Document.MarshalJSON() = (%s, %v), want ({\"hosts\":[\"b\",\"c\"]}, <nil>)", data, err)
+	}
+
+	var zero Document
+	if zero.Value() != nil {
+		t.Errorf("Document.Value() = %v, want <nil>", zero.Value())
+	}
+
+	if err := zero.Set(Pointer{}, map[string]any{}); err != nil {
+		t.Fatalf("Document.Set() = %v, want <nil>", err)
+	}
+}
+
+func TestDocumentConcurrent(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewDocument(map[string]any{
+		"a": 0,
+		"b": 0,
+	})
+	if err != nil {
+		t.Fatalf("NewDocument() = %v, want <nil>", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			for j := range 100 {
+				n := strconv.Itoa(i*100 + j)
+				err := d.Apply(Patch{
+					{Op: "replace", Path: MustParse("/a"), Value: n},
+					{Op: "replace", Path: MustParse("/b"), Value: n},
+				})
+				if err != nil {
+					t.Errorf("Document.Apply() = %v, want <nil>", err)
+				}
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				v := d.Value().(map[string]any)
+				if v["a"] != v["b"] {
+					t.Errorf("Document.Value() = %v, want equal members", v)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,185 @@
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// SetRaw sets the value referred to by the JSON pointer p in the encoded JSON
+// data to the encoded JSON value, and returns the result. If p refers to a
+// member of an object that does not exist, the member is added to the end of
+// the object, and if the last reference token of p is "-" or the length of
+// an array, value is appended to the array. All of data other than the value
+// being set is left exactly as it was, and added members and elements follow
+// the layout of their siblings.
+func SetRaw(data []byte, p Pointer, value []byte) ([]byte, error) {
+	if !json.Valid(value) {
+		var v any
+		return nil, json.Unmarshal(value, &v)
+	}
+
+	s, err := ParseSource(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.IsZero() {
+		return splice(data, s.root.start, s.root.end, value), nil
+	}
+
+	parent, err := s.find(Pointer{tokens: p.tokens[:len(p.tokens)-1]})
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.tokens[len(p.tokens)-1]
+	switch data[parent.start] {
+	case '{':
+		for i := len(parent.children) - 1; i >= 0; i-- {
+			c := &parent.children[i]
+			if c.tok.field == tok.field {
+				return splice(data, c.start, c.end, value), nil
+			}
+		}
+
+		key, err := encodeKey(tok.field)
+		if err != nil {
+			return nil, err
+		}
+
+		colon := []byte(": ")
+		if len(parent.children) > 0 {
+			last := &parent.children[len(parent.children)-1]
+			colon = data[last.keyEnd:last.start]
+		}
+
+		member := slices.Concat(key, colon, value)
+		return insertChild(data, parent, member), nil
+	case '[':
+		if tok.index == -1 && tok.field != "-" {
+			return nil, &invalidArrayIndexError{tok.field}
+		}
+
+		if tok.index != -1 && tok.index < len(parent.children) {
+			c := &parent.children[tok.index]
+			return splice(data, c.start, c.end, value), nil
+		}
+
+		if tok.index > len(parent.children) {
+			return nil, &arrayIndexOutOfBoundsError{tok.index}
+		}
+
+		return insertChild(data, parent, value), nil
+	default:
+		return nil, &valueNotFoundError{tok.field}
+	}
+}
+
+// DeleteRaw removes the value referred to by the JSON pointer p from the
+// encoded JSON data, along with its member name and the separator that
+// follows or precedes it, and returns the result. All of data other than the
+// value being removed is left exactly as it was.
+func DeleteRaw(data []byte, p Pointer) ([]byte, error) {
+	if p.IsZero() {
+		return nil, &invalidPointerError{""}
+	}
+
+	s, err := ParseSource(data)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := s.find(Pointer{tokens: p.tokens[:len(p.tokens)-1]})
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.tokens[len(p.tokens)-1]
+	i := -1
+	switch data[parent.start] {
+	case '{':
+		for i = len(parent.children) - 1; i >= 0; i-- {
+			if parent.children[i].tok.field == tok.field {
+				break
+			}
+		}
+
+		if i == -1 {
+			return nil, &valueNotFoundError{tok.field}
+		}
+	case '[':
+		if tok.index == -1 {
+			if tok.field == "-" {
+				return nil, &arrayIndexOutOfBoundsError{len(parent.children)}
+			}
+
+			return nil, &invalidArrayIndexError{tok.field}
+		}
+
+		if tok.index >= len(parent.children) {
+			return nil, &arrayIndexOutOfBoundsError{tok.index}
+		}
+
+		i = tok.index
+	default:
+		return nil, &valueNotFoundError{tok.field}
+	}
+
+	switch {
+	case len(parent.children) == 1:
+		return splice(data, parent.start+1, parent.end-1, nil), nil
+	case i < len(parent.children)-1:
+		return splice(data, parent.children[i].from(), parent.children[i+1].from(), nil), nil
+	default:
+		return splice(data, parent.children[i-1].end, parent.children[i].end, nil), nil
+	}
+}
+
+// from returns the offset that the node starts at, including its member name.
+func (n *sourceNode) from() int {
+	if n.keyStart != -1 {
+		return n.keyStart
+	}
+
+	return n.start
+}
+
+// insertChild inserts child as the last member or element of parent,
+// separated from its siblings in the same way they are separated from each
+// other.
+func insertChild(data []byte, parent *sourceNode, child []byte) []byte {
+	switch n := len(parent.children); n {
+	case 0:
+		return splice(data, parent.start+1, parent.end-1, child)
+	case 1:
+		sep := slices.Concat([]byte{','}, data[parent.start+1:parent.children[0].from()])
+		if len(sep) == 1 {
+			// With no whitespace to copy, follow the spacing used after
+			// the colons within the parent.
+			if bytes.Contains(data[parent.start:parent.end], []byte(": ")) {
+				sep = append(sep, ' ')
+			}
+		}
+
+		return splice(data, parent.children[0].end, parent.children[0].end, slices.Concat(sep, child))
+	default:
+		sep := data[parent.children[n-2].end:parent.children[n-1].from()]
+		return splice(data, parent.children[n-1].end, parent.children[n-1].end, slices.Concat(sep, child))
+	}
+}
+
+func splice(data []byte, start, end int, value []byte) []byte {
+	return slices.Concat(data[:start], value, data[end:])
+}
+
+func encodeKey(key string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(key); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
@@ -0,0 +1,100 @@
+package jsonpointer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetRaw(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "b": 1.50,
+  "a": [1, 2]
+}
+`
+
+	type test struct {
+		ptr   string
+		value string
+		want  string
+	}
+
+	tests := []test{
+		{"/a/0", `"x"`, "{\n  \"b\": 1.50,\n  \"a\": [\"x\", 2]\n}\n"},
+		{"/a/-", `3`, "{\n  \"b\": 1.50,\n  \"a\": [1, 2, 3]\n}\n"},
+		{"/a/2", `3`, "{\n  \"b\": 1.50,\n  \"a\": [1, 2, 3]\n}\n"},
+		{"/c~1d", `{}`, "{\n  \"b\": 1.50,\n  \"a\": [1, 2],\n  \"c/d\": {}\n}\n"},
+		{"", `null`, "null\n"},
+	}
+
+	for _, test := range tests {
+		result, err := SetRaw([]byte(data), MustParse(test.ptr), []byte(test.value))
+		if err != nil {
+			t.Fatalf("SetRaw(%s) = %v, want <nil>", test.ptr, err)
+		}
+
+		if string(result) != test.want {
+			t.Errorf("SetRaw(%s) = %q, want %q", test.ptr, result, test.want)
+		}
+	}
+
+	result, err := SetRaw([]byte(`{"a": {}, "b": []}`), MustParse("/a/c"), []byte(`1`))
+	if err != nil || string(result) != `{"a": {"c": 1}, "b": []}` {
+		t.Errorf("SetRaw(/a/c) = (%s, %v), want (%s, <nil>)", result, err, `{"a": {"c": 1}, "b": []}`)
+	}
+
+	result, err = SetRaw([]byte(`{"a": {"x": 0}, "b": []}`), MustParse("/a/c"), []byte(`1`))
+	if err != nil || string(result) != `{"a": {"x": 0, "c": 1}, "b": []}` {
+		t.Errorf("SetRaw(/a/c) = (%s, %v), want (%s, <nil>)", result, err, `{"a": {"x": 0, "c": 1}, "b": []}`)
+	}
+
+	_, err = SetRaw([]byte(data), MustParse("/a/3"), []byte(`1`))
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("SetRaw(/a/3) = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	_, err = SetRaw([]byte(data), MustParse("/x/y"), []byte(`1`))
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("SetRaw(/x/y) = %v, want %v", err, ErrValueNotFound)
+	}
+}
+
+func TestDeleteRaw(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "b": 1.50,
+  "a": [1, 2],
+  "c": {"d": true}
+}`
+
+	type test struct {
+		ptr  string
+		want string
+	}
+
+	tests := []test{
+		{"/b", "{\n  \"a\": [1, 2],\n  \"c\": {\"d\": true}\n}"},
+		{"/c", "{\n  \"b\": 1.50,\n  \"a\": [1, 2]\n}"},
+		{"/a/0", "{\n  \"b\": 1.50,\n  \"a\": [2],\n  \"c\": {\"d\": true}\n}"},
+		{"/a/1", "{\n  \"b\": 1.50,\n  \"a\": [1],\n  \"c\": {\"d\": true}\n}"},
+		{"/c/d", "{\n  \"b\": 1.50,\n  \"a\": [1, 2],\n  \"c\": {}\n}"},
+	}
+
+	for _, test := range tests {
+		result, err := DeleteRaw([]byte(data), MustParse(test.ptr))
+		if err != nil {
+			t.Fatalf("DeleteRaw(%s) = %v, want <nil>", test.ptr, err)
+		}
+
+		if string(result) != test.want {
+			t.Errorf("DeleteRaw(%s) = %q, want %q", test.ptr, result, test.want)
+		}
+	}
+
+	_, err := DeleteRaw([]byte(data), MustParse("/x"))
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("DeleteRaw(/x) = %v, want %v", err, ErrValueNotFound)
+	}
+}
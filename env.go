@@ -0,0 +1,184 @@
+package jsonpointer
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EnvOverlay applies environment variables to a document, mapping the name
+// of each variable to a JSON pointer.
+//
+// The part of a name following Prefix and Separator is split by Separator
+// into reference tokens, so that with the Prefix "APP" and the default
+// Separator, the variable APP__DB__HOSTS__0 is applied to /db/hosts/0. Within
+// a reference token, the escape sequence "_xHH_", where HH is a hexadecimal
+// byte, stands for that byte, so that "_x2F_" stands for "/" and "_x5F_"
+// stands for "_" where it would otherwise be read as part of Separator. Only
+// a lower case "x" introduces an escape sequence, so that names such as
+// APP__MAX_X41_Y are used as they are.
+type EnvOverlay struct {
+	// Prefix is the prefix of the names of the variables that are applied.
+	// If Prefix is empty, every variable is applied.
+	Prefix string
+
+	// Separator separates reference tokens in the names of variables. If
+	// Separator is empty, "__" is used.
+	Separator string
+
+	// PreserveCase causes reference tokens that refer to object members to be
+	// used as they appear in names. By default they are converted to lower
+	// case. Reference tokens that refer to struct fields are always matched
+	// against the JSON names of the fields, preferring an exact match, as
+	// with [json.Unmarshal].
+	PreserveCase bool
+}
+
+// Apply applies the variables in environ, in the form "name=value" as
+// returned by [os.Environ], to doc, in order of their names. Each value is
+// set as if by [Pointer.SetCreate] with the [CreateArrays] option, except
+// that a variable naming an existing array index replaces the element
+// instead of inserting before it, so that variables override the values in
+// doc.
+//
+// Where the type of the location being set is known from the type of doc,
+// the value is converted to it: values of string types are used as they
+// are, and other values are decoded as JSON, or as a JSON string if they are
+// not valid JSON. Values are otherwise set as strings.
+//
+// Apply returns the names of the variables that match Prefix but cannot
+// refer to any location in doc because of its type, and the errors from
+// every variable that could not be set joined together.
+func (o EnvOverlay) Apply(doc any, environ []string) ([]string, error) {
+	sep := o.Separator
+	if sep == "" {
+		sep = "__"
+	}
+
+	prefix := o.Prefix
+	if prefix != "" {
+		prefix += sep
+	}
+
+	environ = slices.Clone(environ)
+	slices.Sort(environ)
+
+	var unmatched []string
+	var errs []error
+	for _, env := range environ {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+			continue
+		}
+
+		p, t, ok := o.pointer(reflect.TypeOf(doc), splitEnvName(name[len(prefix):], sep))
+		if !ok {
+			unmatched = append(unmatched, name)
+			continue
+		}
+
+		opts := setOptions{
+			create: true,
+			arrays: true,
+		}
+
+		if err := setCreate(doc, p.tokens, stringValue(t, value), opts); err != nil {
+			errs = append(errs, &envError{name, p.String(), err})
+		}
+	}
+
+	return unmatched, errors.Join(errs...)
+}
+
+// pointer converts the reference tokens from the name of a variable into a
+// JSON pointer, using t, the type of the document, to match struct fields.
+// pointer also returns the type of the location the pointer refers to, or
+// nil if it is not known.
+func (o EnvOverlay) pointer(t reflect.Type, fields []string) (Pointer, reflect.Type, bool) {
+	var p Pointer
+	for _, field := range fields {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t != nil && (t.Kind() == reflect.Interface || t == rawMessageType) {
+			t = nil
+		}
+
+		if t != nil && t.Kind() == reflect.Struct {
+			fields := getStructFields(t)
+			index, ok := fields[field]
+			if !ok {
+				var names []string
+				for name := range fields {
+					if strings.EqualFold(name, field) {
+						names = append(names, name)
+					}
+				}
+
+				if len(names) == 0 {
+					return Pointer{}, nil, false
+				}
+
+				slices.Sort(names)
+				field = names[0]
+				index = fields[field]
+			}
+
+			p = p.child(makeToken(field))
+			t = t.FieldByIndex(index).Type
+			continue
+		}
+
+		if !o.PreserveCase && (t == nil || t.Kind() == reflect.Map) {
+			field = strings.ToLower(field)
+		}
+
+		tok := makeToken(field)
+		if t != nil {
+			if tok.field == "-" && t.Kind() == reflect.Slice {
+				t = t.Elem()
+			} else {
+				var err error
+				t, err = typeStep(t, tok)
+				if err != nil {
+					return Pointer{}, nil, false
+				}
+			}
+		}
+
+		p = p.child(tok)
+	}
+
+	return p, t, true
+}
+
+// splitEnvName splits name into reference tokens separated by sep, replacing
+// escape sequences.
+func splitEnvName(name, sep string) []string {
+	var fields []string
+	var field []byte
+	for len(name) > 0 {
+		if len(name) >= 5 && name[0] == '_' && name[1] == 'x' && name[4] == '_' {
+			if b, err := strconv.ParseUint(name[2:4], 16, 8); err == nil {
+				field = append(field, byte(b))
+				name = name[5:]
+				continue
+			}
+		}
+
+		if strings.HasPrefix(name, sep) {
+			fields = append(fields, string(field))
+			field = field[:0]
+			name = name[len(sep):]
+			continue
+		}
+
+		field = append(field, name[0])
+		name = name[1:]
+	}
+
+	return append(fields, string(field))
+}
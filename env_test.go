@@ -0,0 +1,119 @@
+package jsonpointer
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEnvOverlay(t *testing.T) {
+	t.Parallel()
+
+	type DB struct {
+		Hosts    []string          `json:"hosts"`
+		Port     int               `json:"port"`
+		MaxConns int               `json:"max_conns"`
+		Labels   map[string]string `json:"labels"`
+		Started  time.Time         `json:"started"`
+	}
+
+	type Config struct {
+		DB    *DB  `json:"db"`
+		Debug bool `json:"debug"`
+		Name  string
+		Extra any `json:"extra"`
+	}
+
+	cfg := Config{
+		DB: &DB{
+			Hosts: []string{"a"},
+		},
+	}
+
+	environ := []string{
+		"APP__DB__HOSTS__0=b",
+		"APP__DB__HOSTS__-=c",
+		"APP__DB__PORT=5432",
+		"APP__DB__MAX_CONNS=10",
+		"APP__DB__LABELS__APP_x2F_NAME=d",
+		"APP__DB__STARTED=2026-01-02T03:04:05Z",
+		"APP__DEBUG=true",
+		"APP__NAME=123",
+		"APP__EXTRA__A__0=e",
+		"APP__MISSING=f",
+		"APP__DB__HOSTS__X=g",
+		"APP__DB__PORT__X=h",
+		"APP__DB__HOSTS__5=i",
+		"APP__DEBUG__X=j",
+		"OTHER=k",
+	}
+
+	unmatched, err := EnvOverlay{Prefix: "APP"}.Apply(&cfg, environ)
+	wantUnmatched := []string{"APP__DB__HOSTS__X", "APP__DB__PORT__X", "APP__DEBUG__X", "APP__MISSING"}
+	if !slices.Equal(unmatched, wantUnmatched) {
+		t.Errorf("EnvOverlay.Apply() = %v, want %v", unmatched, wantUnmatched)
+	}
+
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("EnvOverlay.Apply() = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	want := Config{
+		DB: &DB{
+			Hosts:    []string{"b", "c"},
+			Port:     5432,
+			MaxConns: 10,
+			Labels: map[string]string{
+				"app/name": "d",
+			},
+			Started: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		Debug: true,
+		Name:  "123",
+		Extra: map[string]any{
+			"a": []any{"e"},
+		},
+	}
+
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("EnvOverlay.Apply() = %+v, want %+v", cfg, want)
+	}
+
+	var doc any
+	unmatched, err = EnvOverlay{Separator: "_", PreserveCase: true}.Apply(&doc, []string{"A_B=1", "A_x5F_C=2"})
+	if len(unmatched) != 0 || err != nil {
+		t.Fatalf("EnvOverlay.Apply() = (%v, %v), want ([], <nil>)", unmatched, err)
+	}
+
+	wantDoc := map[string]any{
+		"A": map[string]any{
+			"B": "1",
+		},
+		"A_C": "2",
+	}
+
+	if !reflect.DeepEqual(doc, wantDoc) {
+		t.Errorf("EnvOverlay.Apply() = %v, want %v", doc, wantDoc)
+	}
+
+	doc = nil
+	_, err = EnvOverlay{Prefix: "APP"}.Apply(&doc, []string{"APP__MAX_X41_Y=1"})
+	if err != nil {
+		t.Fatalf("EnvOverlay.Apply() = %v, want <nil>", err)
+	}
+
+	wantDoc = map[string]any{
+		"max_x41_y": "1",
+	}
+
+	if !reflect.DeepEqual(doc, wantDoc) {
+		t.Errorf("EnvOverlay.Apply() = %v, want %v", doc, wantDoc)
+	}
+
+	_, err = EnvOverlay{Prefix: "APP"}.Apply(&cfg, []string{"APP__DB__PORT=x"})
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("EnvOverlay.Apply() = %v, want %v", err, ErrTypeMismatch)
+	}
+}
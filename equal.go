@@ -0,0 +1,94 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+)
+
+// EqualValues reports whether a and b represent the same JSON value. Numbers
+// are compared by value regardless of their Go type, including [json.Number],
+// objects are compared regardless of the order of their members, and Go
+// values are compared as they would be encoded as JSON, with struct fields
+// named as they are by [Pointer.Get]. EqualValues returns false if either
+// value cannot be represented as JSON.
+func EqualValues(a, b any) bool {
+	ga, err := toGeneric(reflect.ValueOf(a))
+	if err != nil {
+		return false
+	}
+
+	gb, err := toGeneric(reflect.ValueOf(b))
+	if err != nil {
+		return false
+	}
+
+	return equalGeneric(ga, gb)
+}
+
+// equalGeneric reports whether the generic JSON trees a and b are equal.
+func equalGeneric(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for key, va := range a {
+			vb, ok := b[key]
+			if !ok || !equalGeneric(va, vb) {
+				return false
+			}
+		}
+
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !equalGeneric(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number, int64, uint64, float64:
+		if a == b {
+			return true
+		}
+
+		ra := numberRat(a)
+		rb := numberRat(b)
+		if ra == nil || rb == nil {
+			return false
+		}
+
+		return ra.Cmp(rb) == 0
+	default:
+		return a == b
+	}
+}
+
+func numberRat(value any) *big.Rat {
+	switch v := value.(type) {
+	case json.Number:
+		r, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return nil
+		}
+
+		return r
+	case int64:
+		return new(big.Rat).SetInt64(v)
+	case uint64:
+		return new(big.Rat).SetUint64(v)
+	case float64:
+		return new(big.Rat).SetFloat64(v)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEqualValues(t *testing.T) {
+	t.Parallel()
+
+	type B struct {
+		C int `json:"c"`
+	}
+
+	type A struct {
+		A string  `json:"a"`
+		B []B     `json:"b"`
+		D float32 `json:"d"`
+	}
+
+	var generic any
+	if err := json.Unmarshal([]byte(`{"b": [{"c": 1}], "a": "x", "d": 0.5}`), &generic); err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want <nil>", err)
+	}
+
+	type test struct {
+		a, b  any
+		equal bool
+	}
+
+	tests := []test{
+		{nil, nil, true},
+		{1, 1.0, true},
+		{uint8(1), json.Number("1.0"), true},
+		{json.Number("1e2"), 100, true},
+		{1, 2, false},
+		{1, "1", false},
+		{"a", "a", true},
+		{[]any{1, 2}, []int{1, 2}, true},
+		{[]any{1, 2}, []any{2, 1}, false},
+		{map[string]any{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}, true},
+		{map[string]any{"a": 1}, map[string]any{"a": 1, "b": 2}, false},
+		{&A{A: "x", B: []B{{C: 1}}, D: 0.5}, generic, true},
+		{&A{A: "x", B: []B{{C: 2}}, D: 0.5}, generic, false},
+		{json.RawMessage(`{"a": [1]}`), map[string]any{"a": []float64{1}}, true},
+	}
+
+	for _, test := range tests {
+		if equal := EqualValues(test.a, test.b); equal != test.equal {
+			t.Errorf("EqualValues(%v, %v) = %t, want %t", test.a, test.b, equal, test.equal)
+		}
+	}
+}
@@ -2,16 +2,38 @@ package jsonpointer
 
 import (
 	"errors"
+	"reflect"
 	"strconv"
 )
 
 var (
 	ErrArrayIndexOutOfBounds = errors.New("jsonpointer: array index out of bounds")
 	ErrInvalidArrayIndex     = errors.New("jsonpointer: invalid array index")
+	ErrInvalidAssignment     = errors.New("jsonpointer: invalid assignment")
+	ErrInvalidOperation      = errors.New("jsonpointer: invalid operation")
 	ErrInvalidPointer        = errors.New("jsonpointer: invalid pointer")
+	ErrInvalidTagOption      = errors.New("jsonpointer: invalid tag option")
+	ErrOverlappingPointers   = errors.New("jsonpointer: overlapping pointers")
+	ErrReferenceCycle        = errors.New("jsonpointer: reference cycle")
+	ErrTestFailed            = errors.New("jsonpointer: test failed")
+	ErrTypeMismatch          = errors.New("jsonpointer: type mismatch")
+	ErrUnsupportedValue      = errors.New("jsonpointer: unsupported value")
 	ErrValueNotFound         = errors.New("jsonpointer: value not found")
 )
 
+type applyError struct {
+	assignment string
+	err        error
+}
+
+func (err *applyError) Error() string {
+	return "jsonpointer: cannot apply " + strconv.QuoteToASCII(err.assignment) + ": " + err.err.Error()
+}
+
+func (err *applyError) Unwrap() error {
+	return err.err
+}
+
 type arrayIndexOutOfBoundsError struct {
 	index int
 }
@@ -24,6 +46,46 @@ func (err *arrayIndexOutOfBoundsError) Is(target error) bool {
 	return target == ErrArrayIndexOutOfBounds
 }
 
+type bindError struct {
+	field string
+	tag   string
+	err   error
+}
+
+func (err *bindError) Error() string {
+	return "jsonpointer: cannot bind " + err.field + " to " + strconv.QuoteToASCII(err.tag) + ": " + err.err.Error()
+}
+
+func (err *bindError) Unwrap() error {
+	return err.err
+}
+
+type envError struct {
+	name string
+	ptr  string
+	err  error
+}
+
+func (err *envError) Error() string {
+	return "jsonpointer: cannot apply " + err.name + " to " + strconv.QuoteToASCII(err.ptr) + ": " + err.err.Error()
+}
+
+func (err *envError) Unwrap() error {
+	return err.err
+}
+
+type fieldNotFoundError struct {
+	t reflect.Type
+}
+
+func (err *fieldNotFoundError) Error() string {
+	return "jsonpointer: value not found for " + err.t.String()
+}
+
+func (err *fieldNotFoundError) Is(target error) bool {
+	return target == ErrValueNotFound
+}
+
 type invalidArrayIndexError struct {
 	tok string
 }
@@ -36,6 +98,30 @@ func (err *invalidArrayIndexError) Is(target error) bool {
 	return target == ErrInvalidArrayIndex
 }
 
+type invalidAssignmentError struct {
+	assignment string
+}
+
+func (err *invalidAssignmentError) Error() string {
+	return "jsonpointer: invalid assignment " + strconv.QuoteToASCII(err.assignment)
+}
+
+func (err *invalidAssignmentError) Is(target error) bool {
+	return target == ErrInvalidAssignment
+}
+
+type invalidOperationError struct {
+	op string
+}
+
+func (err *invalidOperationError) Error() string {
+	return "jsonpointer: invalid operation " + strconv.QuoteToASCII(err.op)
+}
+
+func (err *invalidOperationError) Is(target error) bool {
+	return target == ErrInvalidOperation
+}
+
 type invalidPointerError struct {
 	ptr string
 }
@@ -48,6 +134,18 @@ func (err *invalidPointerError) Is(target error) bool {
 	return target == ErrInvalidPointer
 }
 
+type invalidTagOptionError struct {
+	opt string
+}
+
+func (err *invalidTagOptionError) Error() string {
+	return "jsonpointer: invalid tag option " + strconv.QuoteToASCII(err.opt)
+}
+
+func (err *invalidTagOptionError) Is(target error) bool {
+	return target == ErrInvalidTagOption
+}
+
 type invalidTokenError struct {
 	tok string
 }
@@ -60,6 +158,112 @@ func (err *invalidTokenError) Is(target error) bool {
 	return target == ErrInvalidPointer
 }
 
+type loadError struct {
+	uri string
+	err error
+}
+
+func (err *loadError) Error() string {
+	return "jsonpointer: cannot load " + strconv.QuoteToASCII(err.uri) + ": " + err.err.Error()
+}
+
+func (err *loadError) Unwrap() error {
+	return err.err
+}
+
+type operationError struct {
+	index int
+	op    string
+	err   error
+}
+
+func (err *operationError) Error() string {
+	return "jsonpointer: cannot apply operation " + strconv.Itoa(err.index) + " (" + strconv.QuoteToASCII(err.op) + "): " + err.err.Error()
+}
+
+func (err *operationError) Unwrap() error {
+	return err.err
+}
+
+type overlappingPointersError struct {
+	ptr   string
+	other string
+}
+
+func (err *overlappingPointersError) Error() string {
+	return "jsonpointer: pointers " + strconv.QuoteToASCII(err.ptr) + " and " + strconv.QuoteToASCII(err.other) + " overlap"
+}
+
+func (err *overlappingPointersError) Is(target error) bool {
+	return target == ErrOverlappingPointers
+}
+
+type referenceCycleError struct {
+	chain []string
+}
+
+func (err *referenceCycleError) Error() string {
+	s := "jsonpointer: reference cycle "
+	for i, uri := range err.chain {
+		if i != 0 {
+			s += " -> "
+		}
+
+		s += strconv.QuoteToASCII(uri)
+	}
+
+	return s
+}
+
+func (err *referenceCycleError) Is(target error) bool {
+	return target == ErrReferenceCycle
+}
+
+type testFailedError struct {
+	ptr string
+}
+
+func (err *testFailedError) Error() string {
+	return "jsonpointer: test failed at " + strconv.QuoteToASCII(err.ptr)
+}
+
+func (err *testFailedError) Is(target error) bool {
+	return target == ErrTestFailed
+}
+
+type typeMismatchError struct {
+	t     reflect.Type
+	value reflect.Type
+}
+
+func (err *typeMismatchError) Error() string {
+	if err.value == nil {
+		return "jsonpointer: cannot use nil as " + err.t.String()
+	}
+
+	return "jsonpointer: cannot use " + err.value.String() + " as " + err.t.String()
+}
+
+func (err *typeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+type unsupportedValueError struct {
+	t reflect.Type
+}
+
+func (err *unsupportedValueError) Error() string {
+	if err.t == nil {
+		return "jsonpointer: unsupported nil value"
+	}
+
+	return "jsonpointer: unsupported value of type " + err.t.String()
+}
+
+func (err *unsupportedValueError) Is(target error) bool {
+	return target == ErrUnsupportedValue
+}
+
 type valueNotFoundError struct {
 	tok string
 }
@@ -0,0 +1,147 @@
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	numberType        = reflect.TypeFor[json.Number]()
+)
+
+// toGeneric converts value into a generic JSON tree made of map[string]any,
+// []any, string, bool, json.Number, int64, uint64, float64 and nil values.
+// Struct fields are named as they are by getStructFields. Values that
+// implement json.Marshaler or encoding.TextMarshaler are encoded and decoded.
+func toGeneric(value reflect.Value) (any, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	t := value.Type()
+	if t == rawMessageType {
+		if value.Len() == 0 {
+			return nil, nil
+		}
+
+		return decodeGeneric(value.Bytes())
+	}
+
+	if t == numberType {
+		return json.Number(value.String()), nil
+	}
+
+	k := value.Kind()
+	if (k == reflect.Pointer || k == reflect.Interface) && value.IsNil() {
+		return nil, nil
+	}
+
+	if k != reflect.Interface && t.Implements(jsonMarshalerType) {
+		data, err := value.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeGeneric(data)
+	}
+
+	if k != reflect.Interface && t.Implements(textMarshalerType) {
+		data, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		return string(data), nil
+	}
+
+	switch k {
+	case reflect.Interface, reflect.Pointer:
+		return toGeneric(value.Elem())
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		if t.Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(value.Bytes()), nil
+		}
+
+		fallthrough
+	case reflect.Array:
+		result := make([]any, value.Len())
+		for i := range result {
+			elem, err := toGeneric(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = elem
+		}
+
+		return result, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		result := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			elem, err := toGeneric(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+
+			result[mapKeyString(iter.Key())] = elem
+		}
+
+		return result, nil
+	case reflect.Struct:
+		fields := getStructFields(t)
+		result := make(map[string]any, len(fields))
+		for name, index := range fields {
+			field, err := value.FieldByIndexErr(index)
+			if err != nil {
+				continue
+			}
+
+			elem, err := toGeneric(field)
+			if err != nil {
+				return nil, err
+			}
+
+			result[name] = elem
+		}
+
+		return result, nil
+	default:
+		return nil, &unsupportedValueError{t}
+	}
+}
+
+func decodeGeneric(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var result any
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -1,12 +1,48 @@
 package jsonpointer
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 )
 
-// Get resolves the JSON pointer ptr against value and returns the result.
-func Get(ptr string, value any) (any, error) {
+var rawMessageType = reflect.TypeFor[json.RawMessage]()
+
+// GetOption configures how a JSON pointer is resolved by [Get] and
+// [Pointer.Get].
+type GetOption func(*getOptions)
+
+type getOptions struct {
+	raw bool
+}
+
+// KeepRawMessages returns a [GetOption] that causes values found inside a
+// [json.RawMessage] to be returned as a [json.RawMessage], rather than being
+// decoded.
+func KeepRawMessages() GetOption {
+	return func(o *getOptions) {
+		o.raw = true
+	}
+}
+
+// Getter is implemented by types that resolve JSON pointers against
+// themselves, such as those generated by the jsonpointer-gen command.
+// [Pointer.Get] and [Get] call GetPointer instead of using reflection when
+// value implements Getter and no [GetOption] is given.
+type Getter interface {
+	GetPointer(p Pointer) (any, error)
+}
+
+// Get resolves the JSON pointer ptr against value and returns the result. If
+// value implements [Getter] and no options are given, its GetPointer method
+// is used instead.
+//
+// If the pointer continues past a [json.RawMessage] value, the remaining
+// reference tokens are resolved against the encoded JSON and the result is
+// decoded as if by [json.Unmarshal] into an any value. An empty
+// [json.RawMessage] is treated as a missing value, and one that is not valid
+// JSON, including one with data following its value, causes an error.
+func Get(ptr string, value any, opts ...GetOption) (any, error) {
 	if ptr == "" {
 		return value, nil
 	}
@@ -15,98 +51,104 @@ func Get(ptr string, value any) (any, error) {
 		return nil, &invalidPointerError{ptr}
 	}
 
+	if g, ok := value.(Getter); ok && len(opts) == 0 {
+		p, err := Parse(ptr)
+		if err != nil {
+			return nil, err
+		}
+
+		return g.GetPointer(p)
+	}
+
 	remaining := ptr[1:]
-	count := strings.Count(remaining, "/")
 	result := value
-	var i int
+	var next int
 	var tok token
-	var ok bool
-	for i = 0; i < count; i++ {
-		next := strings.IndexByte(remaining, '/')
+	for {
+		next = strings.IndexByte(remaining, '/')
+
+		var err error
 		if next == -1 {
-			return nil, &invalidPointerError{ptr}
+			tok, err = parseToken(remaining)
+		} else {
+			tok, err = parseToken(remaining[:next])
 		}
 
-		var err error
-		tok, err = parseToken(remaining[:next])
 		if err != nil {
 			return nil, err
 		}
 
+		var ok bool
 		result, ok, err = get(tok, result)
 		if err != nil {
 			return nil, err
 		}
 
-		remaining = remaining[next+1:]
-
 		if !ok {
-			i++
 			break
 		}
-	}
-
-	if ok {
-		tok, err := parseToken(remaining)
-		if err != nil {
-			return nil, err
-		}
-
-		result, ok, err = get(tok, result)
-		if err != nil {
-			return nil, err
-		}
 
-		if ok {
+		if next == -1 {
 			return result, nil
 		}
+
+		remaining = remaining[next+1:]
 	}
 
 	refResult := reflect.ValueOf(result)
-	if err := getReflect(tok, &refResult); err != nil {
-		return nil, err
-	}
+	for {
+		if err := getReflect(tok, &refResult); err != nil {
+			if err != errRawMessage {
+				return nil, err
+			}
 
-	for ; i < count; i++ {
-		next := strings.IndexByte(remaining, '/')
-		if next == -1 {
-			return nil, &invalidPointerError{ptr}
-		}
+			rest, err := Parse(ptr[len(ptr)-len(remaining)-1:])
+			if err != nil {
+				return nil, err
+			}
 
-		var err error
-		tok, err = parseToken(remaining[:next])
-		if err != nil {
-			return nil, err
+			return getRawMessage(rest.tokens, refResult.Bytes(), opts)
 		}
 
-		if err := getReflect(tok, &refResult); err != nil {
-			return nil, err
+		if next == -1 {
+			return refResult.Interface(), nil
 		}
 
 		remaining = remaining[next+1:]
-	}
+		next = strings.IndexByte(remaining, '/')
 
-	var err error
-	tok, err = parseToken(remaining)
-	if err != nil {
-		return nil, err
-	}
+		var err error
+		if next == -1 {
+			tok, err = parseToken(remaining)
+		} else {
+			tok, err = parseToken(remaining[:next])
+		}
 
-	if err := getReflect(tok, &refResult); err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	return refResult.Interface(), nil
 }
 
 // Get resolves the JSON pointer parsed into p against value and returns the
-// result.
-func (p Pointer) Get(value any) (any, error) {
+// result. If value implements [Getter] and no options are given, its
+// GetPointer method is used instead.
+//
+// If the pointer continues past a [json.RawMessage] value, the remaining
+// reference tokens are resolved against the encoded JSON and the result is
+// decoded as if by [json.Unmarshal] into an any value. An empty
+// [json.RawMessage] is treated as a missing value, and one that is not valid
+// JSON, including one with data following its value, causes an error.
+func (p Pointer) Get(value any, opts ...GetOption) (any, error) {
+	if g, ok := value.(Getter); ok && len(opts) == 0 {
+		return g.GetPointer(p)
+	}
+
 	result := value
 
 	var i int
 	var tok token
-	var ok bool
+	ok := true
 	for i, tok = range p.tokens {
 		var err error
 		result, ok, err = get(tok, result)
@@ -124,10 +166,36 @@ func (p Pointer) Get(value any) (any, error) {
 	}
 
 	refResult := reflect.ValueOf(result)
-	for _, tok = range p.tokens[i:] {
+	for j, tok := range p.tokens[i:] {
 		if err := getReflect(tok, &refResult); err != nil {
+			if err != errRawMessage {
+				return nil, err
+			}
+
+			return getRawMessage(p.tokens[i+j:], refResult.Bytes(), opts)
+		}
+	}
+
+	return refResult.Interface(), nil
+}
+
+func getToken(tok token, value any) (any, error) {
+	result, ok, err := get(tok, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return result, nil
+	}
+
+	refResult := reflect.ValueOf(value)
+	if err := getReflect(tok, &refResult); err != nil {
+		if err != errRawMessage {
 			return nil, err
 		}
+
+		return getRawMessage([]token{tok}, refResult.Bytes(), nil)
 	}
 
 	return refResult.Interface(), nil
@@ -225,6 +293,10 @@ func getReflect(tok token, value *reflect.Value) error {
 		k = value.Kind()
 	}
 
+	if value.Type() == rawMessageType {
+		return errRawMessage
+	}
+
 	switch k {
 	case reflect.Array, reflect.Slice:
 		if tok.index == -1 {
@@ -242,7 +314,12 @@ func getReflect(tok token, value *reflect.Value) error {
 		*value = value.Index(tok.index)
 		return nil
 	case reflect.Map:
-		*value = value.MapIndex(reflect.ValueOf(tok.field))
+		key, ok := mapKey(value.Type().Key(), tok.field)
+		if !ok {
+			return &valueNotFoundError{tok.field}
+		}
+
+		*value = value.MapIndex(key)
 		if !value.IsValid() {
 			return &valueNotFoundError{tok.field}
 		}
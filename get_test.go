@@ -1,6 +1,10 @@
 package jsonpointer
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
 
 func TestGet(t *testing.T) {
 	t.Parallel()
@@ -198,6 +202,141 @@ func TestPointerGet(t *testing.T) {
 	}
 }
 
+func TestPointerGetMapKey(t *testing.T) {
+	t.Parallel()
+
+	value := map[int]string{
+		1: "a",
+	}
+
+	result, err := MustParse("/1").Get(value)
+	if result != "a" || err != nil {
+		t.Fatalf("Pointer.Get() = (%v, %v), want (a, <nil>)", result, err)
+	}
+
+	_, err = MustParse("/b").Get(value)
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Pointer.Get() = %v, want %v", err, ErrValueNotFound)
+	}
+}
+
+func TestPointerGetRawMessage(t *testing.T) {
+	t.Parallel()
+
+	type Envelope struct {
+		Body json.RawMessage `json:"body"`
+	}
+
+	value := &Envelope{
+		Body: json.RawMessage(`{"a": [1, {"b": "c"}, 3], "d": {"e": null}}`),
+	}
+
+	ptr := MustParse("/body/a/1/b")
+	result, err := ptr.Get(value)
+	if result != "c" || err != nil {
+		t.Fatalf("Pointer.Get() = (%v, %v), want (c, <nil>)", result, err)
+	}
+
+	result, err = Get("/body/a/1/b", value)
+	if result != "c" || err != nil {
+		t.Fatalf("Get() = (%v, %v), want (c, <nil>)", result, err)
+	}
+
+	result, err = MustParse("/body/a/1").Get(value, KeepRawMessages())
+	if raw, ok := result.(json.RawMessage); !ok || string(raw) != `{"b": "c"}` || err != nil {
+		t.Fatalf("Pointer.Get() = (%s, %v), want ({\"b\": \"c\"}, <nil>)", result, err)
+	}
+
+	result, err = MustParse("/body").Get(value)
+	if _, ok := result.(json.RawMessage); !ok || err != nil {
+		t.Fatalf("Pointer.Get() = (%T, %v), want (json.RawMessage, <nil>)", result, err)
+	}
+
+	var generic any = map[string]any{
+		"body": value.Body,
+	}
+
+	result, err = MustParse("/body/d/e").Get(generic)
+	if result != nil || err != nil {
+		t.Fatalf("Pointer.Get() = (%v, %v), want (<nil>, <nil>)", result, err)
+	}
+
+	_, err = MustParse("/body/x").Get(generic)
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Pointer.Get() = %v, want %v", err, ErrValueNotFound)
+	}
+
+	_, err = MustParse("/body/a/3").Get(generic)
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("Pointer.Get() = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	_, err = MustParse("/body/a/-").Get(generic)
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("Pointer.Get() = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	_, err = MustParse("/body/a/b").Get(generic)
+	if !errors.Is(err, ErrInvalidArrayIndex) {
+		t.Errorf("Pointer.Get() = %v, want %v", err, ErrInvalidArrayIndex)
+	}
+
+	for _, body := range []json.RawMessage{nil, {}, json.RawMessage(" ")} {
+		_, err = MustParse("/body/a").Get(&Envelope{Body: body})
+		if !errors.Is(err, ErrValueNotFound) {
+			t.Errorf("Pointer.Get(%q) = %v, want %v", body, err, ErrValueNotFound)
+		}
+	}
+
+	_, err = MustParse("/body/b").Get(&Envelope{Body: json.RawMessage(`{"b": 1} garbage`)})
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Pointer.Get() = %v, want *json.SyntaxError", err)
+	}
+}
+
+func TestPointerGetEmpty(t *testing.T) {
+	t.Parallel()
+
+	var p Pointer
+	result, err := p.Get(nil)
+	if result != nil || err != nil {
+		t.Fatalf("Pointer.Get() = (%v, %v), want (<nil>, <nil>)", result, err)
+	}
+
+	value := map[string]any{
+		"a": "b",
+	}
+
+	result, err = p.Get(value)
+	if _, ok := result.(map[string]any); !ok || err != nil {
+		t.Fatalf("Pointer.Get() = (%v, %v), want (map[a:b], <nil>)", result, err)
+	}
+}
+
+func TestPointerGetNilEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type Options struct {
+		Timeout int `json:"timeout"`
+	}
+
+	type Host struct {
+		*Options
+		Port int `json:"port"`
+	}
+
+	_, err := MustParse("/timeout").Get(Host{})
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Pointer.Get() = %v, want %v", err, ErrValueNotFound)
+	}
+
+	result, err := MustParse("/timeout").Get(Host{Options: &Options{Timeout: 1}})
+	if result != 1 || err != nil {
+		t.Errorf("Pointer.Get() = (%v, %v), want (1, <nil>)", result, err)
+	}
+}
+
 func BenchmarkGetMap(b *testing.B) {
 	b.ReportAllocs()
 
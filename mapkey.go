@@ -0,0 +1,71 @@
+package jsonpointer
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// mapKey converts the reference token field into a key of the map key type
+// t, in the same way that encoding/json decodes object member names into map
+// keys.
+func mapKey(t reflect.Type, field string) (reflect.Value, bool) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		key := reflect.New(t)
+		if err := key.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(field)); err != nil {
+			return reflect.Value{}, false
+		}
+
+		return key.Elem(), true
+	}
+
+	key := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		key.SetString(field)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(field, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(field, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+
+		key.SetUint(n)
+	default:
+		return reflect.Value{}, false
+	}
+
+	return key, true
+}
+
+// mapKeyString converts the map key key into a reference token, in the same
+// way that encoding/json encodes map keys as object member names.
+func mapKeyString(key reflect.Value) string {
+	if key.Kind() != reflect.String && key.Type().Implements(textMarshalerType) {
+		if key.Kind() != reflect.Pointer || !key.IsNil() {
+			if data, err := key.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+				return string(data)
+			}
+		}
+	}
+
+	switch key.Kind() {
+	case reflect.String:
+		return key.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10)
+	default:
+		return fmt.Sprint(key.Interface())
+	}
+}
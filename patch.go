@@ -0,0 +1,252 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Operation is a single JSON Patch operation, as defined by RFC 6902. Op is
+// one of "add", "remove", "replace", "move", "copy" or "test". From is only
+// used by the "move" and "copy" operations, and Value is only used by the
+// "add", "replace" and "test" operations.
+type Operation struct {
+	Op    string
+	Path  Pointer
+	From  Pointer
+	Value any
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. Only the members
+// used by the operation are included in the encoded object.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	type operation struct {
+		Op    string   `json:"op"`
+		From  *Pointer `json:"from,omitempty"`
+		Path  Pointer  `json:"path"`
+		Value *any     `json:"value,omitempty"`
+	}
+
+	o := operation{
+		Op:   op.Op,
+		Path: op.Path,
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		o.Value = &op.Value
+	case "move", "copy":
+		o.From = &op.From
+	}
+
+	return json.Marshal(o)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (op *Operation) UnmarshalJSON(data []byte) error {
+	var o struct {
+		Op    string  `json:"op"`
+		From  Pointer `json:"from"`
+		Path  Pointer `json:"path"`
+		Value any     `json:"value"`
+	}
+
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+
+	*op = Operation{
+		Op:    o.Op,
+		Path:  o.Path,
+		From:  o.From,
+		Value: o.Value,
+	}
+
+	return nil
+}
+
+// Patch is a JSON Patch document, as defined by RFC 6902.
+type Patch []Operation
+
+// Apply applies the patch to doc and returns the resulting document. doc is
+// first converted to a generic tree of map[string]any and []any values, and
+// is not modified. The operations are applied in order, and if any of them
+// fails Apply returns an error instead of a partially patched document. The
+// "test" operation compares values as they are by [EqualValues], and returns
+// an error matching [ErrTestFailed] if they are not equal.
+func (p Patch) Apply(doc any) (any, error) {
+	root, err := toGeneric(reflect.ValueOf(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	return p.apply(root)
+}
+
+// apply applies the patch to the generic tree root, copying any objects and
+// arrays that it modifies rather than modifying them in place.
+func (p Patch) apply(root any) (any, error) {
+	for i, op := range p {
+		var err error
+		root, err = op.apply(root)
+		if err != nil {
+			return nil, &operationError{i, op.Op, err}
+		}
+	}
+
+	return root, nil
+}
+
+func (op Operation) apply(root any) (any, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		value, err := toGeneric(reflect.ValueOf(op.Value))
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.Op {
+		case "add":
+			return update(root, op.Path.tokens, updateAdd, value)
+		case "replace":
+			return update(root, op.Path.tokens, updateReplace, value)
+		}
+
+		current, err := op.Path.Get(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if !EqualValues(current, value) {
+			return nil, &testFailedError{op.Path.String()}
+		}
+
+		return root, nil
+	case "remove":
+		return update(root, op.Path.tokens, updateRemove, nil)
+	case "move", "copy":
+		value, err := op.From.Get(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "move" {
+			if op.From.Equal(op.Path) {
+				return root, nil
+			}
+
+			if op.From.isPrefixOf(op.Path) {
+				return nil, &invalidOperationError{op.Op}
+			}
+
+			root, err = update(root, op.From.tokens, updateRemove, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return update(root, op.Path.tokens, updateAdd, value)
+	default:
+		return nil, &invalidOperationError{op.Op}
+	}
+}
+
+type updateMode uint8
+
+const (
+	// updateAdd adds a value as the "add" operation does, inserting it into
+	// arrays.
+	updateAdd updateMode = iota
+
+	// updateReplace replaces a value that must already exist.
+	updateReplace
+
+	// updateRemove removes a value that must already exist.
+	updateRemove
+
+	// updateSet sets a value as Pointer.Set does, replacing array elements.
+	updateSet
+)
+
+// update returns a copy of the generic tree node in which the location
+// referred to by tokens has been updated with value according to mode. Only
+// the objects and arrays along tokens are copied, and node is not modified.
+func update(node any, tokens []token, mode updateMode, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	tok := tokens[0]
+	last := len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[tok.field]
+		if !ok && (!last || mode == updateReplace || mode == updateRemove) {
+			return nil, &valueNotFoundError{tok.field}
+		}
+
+		c := make(map[string]any, len(n)+1)
+		maps.Copy(c, n)
+		switch {
+		case last && mode == updateRemove:
+			delete(c, tok.field)
+		case last:
+			c[tok.field] = value
+		default:
+			child, err := update(child, tokens[1:], mode, value)
+			if err != nil {
+				return nil, err
+			}
+
+			c[tok.field] = child
+		}
+
+		return c, nil
+	case []any:
+		if last && (mode == updateAdd || mode == updateSet) && (tok.field == "-" || tok.index == len(n)) {
+			c := make([]any, len(n), len(n)+1)
+			copy(c, n)
+			return append(c, value), nil
+		}
+
+		if tok.index == -1 {
+			if tok.field == "-" {
+				return nil, &arrayIndexOutOfBoundsError{len(n)}
+			}
+
+			return nil, &invalidArrayIndexError{tok.field}
+		}
+
+		if tok.index >= len(n) {
+			return nil, &arrayIndexOutOfBoundsError{tok.index}
+		}
+
+		switch {
+		case last && mode == updateAdd:
+			c := make([]any, 0, len(n)+1)
+			c = append(c, n[:tok.index]...)
+			c = append(c, value)
+			return append(c, n[tok.index:]...), nil
+		case last && mode == updateRemove:
+			c := make([]any, 0, len(n)-1)
+			c = append(c, n[:tok.index]...)
+			return append(c, n[tok.index+1:]...), nil
+		case last:
+			c := slices.Clone(n)
+			c[tok.index] = value
+			return c, nil
+		default:
+			child, err := update(n[tok.index], tokens[1:], mode, value)
+			if err != nil {
+				return nil, err
+			}
+
+			c := slices.Clone(n)
+			c[tok.index] = child
+			return c, nil
+		}
+	default:
+		return nil, &valueNotFoundError{tok.field}
+	}
+}
@@ -0,0 +1,79 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPatchApply(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		doc   string
+		patch string
+		want  string
+		err   error
+	}
+
+	tests := []test{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`, nil},
+		{`{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`, nil},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`, nil},
+		{`{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`, nil},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`, nil},
+		{`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`, `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`, nil},
+		{`{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`, nil},
+		{`{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`, `{"baz": "qux", "foo": ["a", 2, "c"]}`, nil},
+		{`{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`, ``, ErrTestFailed},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"foo": "bar", "child": {"grandchild": {}}}`, nil},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, ``, ErrValueNotFound},
+		{`{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}]`, `{"/": 9, "~1": 10}`, nil},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`, nil},
+		{`{"foo": {"bar": 1}}`, `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "replace", "path": "/baz/bar", "value": 2}]`, `{"foo": {"bar": 1}, "baz": {"bar": 2}}`, nil},
+		{`{"foo": {"bar": 1}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`, ``, ErrInvalidOperation},
+		{`{"foo": [1]}`, `[{"op": "replace", "path": "/foo/1", "value": 2}]`, ``, ErrArrayIndexOutOfBounds},
+		{`{"foo": 1}`, `[{"op": "add", "path": "/bar", "value": 2}, {"op": "remove", "path": "/baz"}]`, ``, ErrValueNotFound},
+		{`{}`, `[{"op": "invalid", "path": ""}]`, ``, ErrInvalidOperation},
+	}
+
+	for _, test := range tests {
+		var doc any
+		if err := json.Unmarshal([]byte(test.doc), &doc); err != nil {
+			t.Fatalf("json.Unmarshal(%s) = %v, want <nil>", test.doc, err)
+		}
+
+		var patch Patch
+		if err := json.Unmarshal([]byte(test.patch), &patch); err != nil {
+			t.Fatalf("json.Unmarshal(%s) = %v, want <nil>", test.patch, err)
+		}
+
+		result, err := patch.Apply(doc)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("Patch(%s).Apply() = %v, want %v", test.patch, err, test.err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Patch(%s).Apply() = %v, want <nil>", test.patch, err)
+		}
+
+		var want any
+		if err := json.Unmarshal([]byte(test.want), &want); err != nil {
+			t.Fatalf("json.Unmarshal(%s) = %v, want <nil>", test.want, err)
+		}
+
+		if !EqualValues(result, want) {
+			t.Errorf("Patch(%s).Apply() = %v, want %v", test.patch, result, want)
+		}
+
+		var original any
+		json.Unmarshal([]byte(test.doc), &original)
+		if !EqualValues(doc, original) {
+			t.Errorf("Patch(%s).Apply() modified the document to %v", test.patch, doc)
+		}
+	}
+}
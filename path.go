@@ -0,0 +1,119 @@
+package jsonpointer
+
+import (
+	"reflect"
+	"strconv"
+)
+
+var getterType = reflect.TypeFor[Getter]()
+
+// Path is a JSON pointer bound to the type of the values it is resolved
+// against, Root, and the type of the value it refers to, V. A Path is checked
+// against Root when it is created, so that it can only fail to resolve
+// because of the contents of a value, not its type.
+type Path[Root, V any] struct {
+	p        Pointer
+	accessor Accessor
+	compiled bool
+}
+
+// MustPath is like [NewPath] but parses the JSON pointer ptr and panics if it
+// cannot be parsed or bound, instead of returning an error.
+func MustPath[Root, V any](ptr string) Path[Root, V] {
+	p, err := Parse(ptr)
+	if err != nil {
+		panic("jsonpointer.MustPath(" + strconv.Quote(ptr) + "): invalid pointer")
+	}
+
+	path, err := NewPath[Root, V](p)
+	if err != nil {
+		panic("jsonpointer.MustPath(" + strconv.Quote(ptr) + "): " + err.Error())
+	}
+
+	return path
+}
+
+// NewPath binds the JSON pointer p to the types Root and V. NewPath returns
+// an error if p cannot resolve against a value of type Root, as reported by
+// [Pointer.CheckType], or if the value it resolves to cannot be assigned to
+// V. If p continues past an interface or [json.RawMessage] value, the type of
+// the value it resolves to is checked when it is resolved.
+func NewPath[Root, V any](p Pointer) (Path[Root, V], error) {
+	t := reflect.TypeFor[Root]()
+	result, err := p.CheckType(t)
+	if err != nil {
+		return Path[Root, V]{}, err
+	}
+
+	if result.Kind() != reflect.Interface && result != rawMessageType {
+		if v := reflect.TypeFor[V](); !result.AssignableTo(v) {
+			return Path[Root, V]{}, &typeMismatchError{v, result}
+		}
+	}
+
+	path := Path[Root, V]{
+		p: p,
+	}
+
+	// Values that resolve pointers themselves are left to Pointer.Get, as
+	// are interfaces, whose dynamic types Compile cannot know.
+	if t.Kind() != reflect.Interface && !t.Implements(getterType) {
+		path.accessor, err = Compile(p, t)
+		if err != nil {
+			return Path[Root, V]{}, err
+		}
+
+		path.compiled = true
+	}
+
+	return path, nil
+}
+
+// Get resolves the path against root and returns the result. If the path
+// continues past an interface or [json.RawMessage] value, the result is
+// converted to V as it is by [Pointer.Set], and Get returns an error matching
+// [ErrTypeMismatch] if it cannot be.
+func (p Path[Root, V]) Get(root Root) (V, error) {
+	var result any
+	var err error
+	if p.compiled {
+		result, err = p.accessor.Get(root)
+	} else {
+		result, err = p.p.Get(root)
+	}
+
+	var v V
+	if err != nil {
+		return v, err
+	}
+
+	if r, ok := result.(V); ok {
+		return r, nil
+	}
+
+	if err := assign(reflect.ValueOf(&v).Elem(), result); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// Pointer returns the JSON pointer that the path was created from.
+func (p Path[Root, V]) Pointer() Pointer {
+	return p.p
+}
+
+// Set sets the value referred to by the path within root to value, as
+// [Pointer.Set] does.
+func (p Path[Root, V]) Set(root *Root, value V) error {
+	if root == nil {
+		return &unsupportedValueError{reflect.TypeFor[*Root]()}
+	}
+
+	return p.p.Set(root, value)
+}
+
+// String returns the path's JSON pointer as a string.
+func (p Path[Root, V]) String() string {
+	return p.p.String()
+}
@@ -0,0 +1,100 @@
+package jsonpointer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	type Host struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+
+	type Config struct {
+		Hosts []Host `json:"hosts"`
+		Extra any    `json:"extra"`
+	}
+
+	port := MustPath[Config, int]("/hosts/0/port")
+	if port.String() != "/hosts/0/port" {
+		t.Errorf("Path.String() = %s, want /hosts/0/port", port.String())
+	}
+
+	cfg := Config{
+		Hosts: []Host{
+			{Name: "a", Port: 1},
+		},
+		Extra: map[string]any{
+			"n": 2.0,
+			"s": "b",
+		},
+	}
+
+	result, err := port.Get(cfg)
+	if result != 1 || err != nil {
+		t.Fatalf("Path.Get() = (%v, %v), want (1, <nil>)", result, err)
+	}
+
+	if err := port.Set(&cfg, 3); err != nil {
+		t.Fatalf("Path.Set() = %v, want <nil>", err)
+	}
+
+	if cfg.Hosts[0].Port != 3 {
+		t.Errorf("Path.Set() set %d, want 3", cfg.Hosts[0].Port)
+	}
+
+	_, err = MustPath[Config, int]("/hosts/1/port").Get(cfg)
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("Path.Get() = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	n, err := MustPath[Config, int]("/extra/n").Get(cfg)
+	if n != 2 || err != nil {
+		t.Errorf("Path.Get() = (%v, %v), want (2, <nil>)", n, err)
+	}
+
+	_, err = MustPath[Config, int]("/extra/s").Get(cfg)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Path.Get() = %v, want %v", err, ErrTypeMismatch)
+	}
+
+	host, err := MustPath[*Config, Host]("/hosts/0").Get(&cfg)
+	if host.Name != "a" || err != nil {
+		t.Errorf("Path.Get() = (%v, %v), want ({a 3}, <nil>)", host, err)
+	}
+
+	s, err := MustPath[any, string]("/hosts/0/name").Get(cfg)
+	if s != "a" || err != nil {
+		t.Errorf("Path.Get() = (%v, %v), want (a, <nil>)", s, err)
+	}
+
+	_, err = NewPath[Config, string](MustParse("/hosts/0/port"))
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("NewPath() = %v, want %v", err, ErrTypeMismatch)
+	}
+
+	_, err = NewPath[Config, int](MustParse("/hosts/0/missing"))
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("NewPath() = %v, want %v", err, ErrValueNotFound)
+	}
+
+	err = port.Set(nil, 1)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Path.Set() = %v, want %v", err, ErrUnsupportedValue)
+	}
+}
+
+func TestMustPathPanic(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustPath() did not panic")
+		}
+	}()
+
+	MustPath[struct{ A int }, string]("/A")
+}
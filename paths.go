@@ -0,0 +1,108 @@
+package jsonpointer
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// Paths returns an iterator over the locations within a value of type t, as
+// patterns, starting with the root. Elements of slices, arrays and maps are
+// represented by a "*" reference token, and struct fields are named as they
+// are by [Pointer.Get] and yielded in the order they are declared.
+//
+// Locations below a value held in an interface or a [json.RawMessage], or
+// below a value whose type is already being expanded, cannot be listed. For
+// such a value the location of the value is followed by a pattern ending in
+// "**".
+//
+// There are no locations within a nil type, so if t is nil the iterator
+// yields nothing.
+func Paths(t reflect.Type) iter.Seq[Pattern] {
+	return func(yield func(Pattern) bool) {
+		if t == nil {
+			return
+		}
+
+		w := pathWalker{
+			yield:     yield,
+			expanding: make(map[reflect.Type]struct{}),
+		}
+
+		w.walk(t)
+	}
+}
+
+type pathWalker struct {
+	yield     func(Pattern) bool
+	tokens    []patternToken
+	expanding map[reflect.Type]struct{}
+}
+
+func (w *pathWalker) emit() bool {
+	return w.yield(Pattern{
+		tokens: slices.Clone(w.tokens),
+	})
+}
+
+func (w *pathWalker) walk(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if !w.emit() {
+		return false
+	}
+
+	_, expanding := w.expanding[t]
+	if expanding || t.Kind() == reflect.Interface || t == rawMessageType {
+		return w.descend(patternToken{kind: patternAnyDeep}, nil)
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return true
+		}
+
+		w.expanding[t] = struct{}{}
+		defer delete(w.expanding, t)
+		return w.descend(patternToken{kind: patternAny}, t.Elem())
+	case reflect.Struct:
+		w.expanding[t] = struct{}{}
+		defer delete(w.expanding, t)
+
+		fields := getStructFields(t)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+
+		slices.SortFunc(names, func(a, b string) int {
+			return slices.Compare(fields[a], fields[b])
+		})
+
+		for _, name := range names {
+			if !w.descend(patternToken{field: name}, t.FieldByIndex(fields[name]).Type) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// descend walks the type t at the location tok below the current location. If
+// t is nil, only the location itself is yielded.
+func (w *pathWalker) descend(tok patternToken, t reflect.Type) bool {
+	w.tokens = append(w.tokens, tok)
+	defer func() {
+		w.tokens = w.tokens[:len(w.tokens)-1]
+	}()
+
+	if t == nil {
+		return w.emit()
+	}
+
+	return w.walk(t)
+}
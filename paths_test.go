@@ -0,0 +1,64 @@
+package jsonpointer
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+
+	type Address struct {
+		City string `json:"city"`
+	}
+
+	type User struct {
+		Name    string   `json:"name"`
+		Address *Address `json:"address"`
+		Friends []*User  `json:"friends"`
+		Meta    any      `json:"meta"`
+		Avatar  []byte   `json:"avatar"`
+	}
+
+	type Root struct {
+		Users map[string]User `json:"users"`
+		Count [2]int          `json:"count"`
+	}
+
+	var paths []string
+	for p := range Paths(reflect.TypeFor[*Root]()) {
+		paths = append(paths, p.String())
+	}
+
+	want := []string{
+		"",
+		"/users",
+		"/users/*",
+		"/users/*/name",
+		"/users/*/address",
+		"/users/*/address/city",
+		"/users/*/friends",
+		"/users/*/friends/*",
+		"/users/*/friends/*/**",
+		"/users/*/meta",
+		"/users/*/meta/**",
+		"/users/*/avatar",
+		"/count",
+		"/count/*",
+	}
+
+	if !slices.Equal(paths, want) {
+		t.Errorf("Paths() = %q, want %q", paths, want)
+	}
+
+	for p := range Paths(reflect.TypeFor[*Root]()) {
+		if p.String() == "/users/*" {
+			break
+		}
+	}
+
+	for p := range Paths(reflect.TypeOf(nil)) {
+		t.Errorf("Paths(nil) yielded %s, want nothing", p)
+	}
+}
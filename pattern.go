@@ -0,0 +1,158 @@
+package jsonpointer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Pattern represents a parsed JSON pointer pattern. A pattern is written and
+// escaped like a JSON pointer, except that a reference token consisting of a
+// single "*" matches any one reference token, and a reference token
+// consisting of "**" matches any sequence of zero or more reference tokens.
+// Within a pattern, the escape sequence "~2" stands for "*", so that the
+// pattern "/~2" matches only a member named "*" and "/~2~2" only a member
+// named "**".
+type Pattern struct {
+	tokens []patternToken
+}
+
+type patternKind uint8
+
+const (
+	patternField patternKind = iota
+	patternAny
+	patternAnyDeep
+)
+
+type patternToken struct {
+	field string
+	kind  patternKind
+}
+
+// MustParsePattern is like [ParsePattern] but panics if the provided pattern
+// cannot be parsed, instead of returning an error.
+func MustParsePattern(pattern string) Pattern {
+	p, err := ParsePattern(pattern)
+	if err != nil {
+		panic("jsonpointer.MustParsePattern(" + strconv.Quote(pattern) + "): invalid pattern")
+	}
+
+	return p
+}
+
+// ParsePattern parses the JSON pointer pattern pattern.
+func ParsePattern(pattern string) (Pattern, error) {
+	if pattern == "" {
+		return Pattern{}, nil
+	}
+
+	if pattern[0] != '/' {
+		return Pattern{}, &invalidPointerError{pattern}
+	}
+
+	var tokens []patternToken
+	for tok := range strings.SplitSeq(pattern[1:], "/") {
+		t, err := parsePatternToken(tok)
+		if err != nil {
+			return Pattern{}, err
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return Pattern{
+		tokens: tokens,
+	}, nil
+}
+
+func parsePatternToken(tok string) (patternToken, error) {
+	switch tok {
+	case "*":
+		return patternToken{kind: patternAny}, nil
+	case "**":
+		return patternToken{kind: patternAnyDeep}, nil
+	}
+
+	if strings.IndexByte(tok, '~') == -1 {
+		return patternToken{field: tok}, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(tok))
+	for i := 0; i < len(tok); i++ {
+		if tok[i] != '~' {
+			b.WriteByte(tok[i])
+			continue
+		}
+
+		if i+1 == len(tok) {
+			return patternToken{}, &invalidTokenError{tok}
+		}
+
+		i++
+		switch tok[i] {
+		case '0':
+			b.WriteByte('~')
+		case '1':
+			b.WriteByte('/')
+		case '2':
+			b.WriteByte('*')
+		default:
+			return patternToken{}, &invalidTokenError{tok}
+		}
+	}
+
+	return patternToken{field: b.String()}, nil
+}
+
+// Match reports whether the JSON pointer p matches the Pattern.
+func (p Pattern) Match(ptr Pointer) bool {
+	return matchTokens(p.tokens, ptr.tokens)
+}
+
+// String returns a string representation of the Pattern value.
+func (p Pattern) String() string {
+	var buf []byte
+	for _, tok := range p.tokens {
+		buf = append(buf, '/')
+		switch {
+		case tok.kind == patternAny:
+			buf = append(buf, '*')
+		case tok.kind == patternAnyDeep:
+			buf = append(buf, "**"...)
+		case tok.field == "*":
+			buf = append(buf, "~2"...)
+		case tok.field == "**":
+			buf = append(buf, "~2~2"...)
+		default:
+			buf = appendToken(buf, tok.field)
+		}
+	}
+
+	return string(buf)
+}
+
+func matchTokens(pattern []patternToken, tokens []token) bool {
+	for i, tok := range pattern {
+		switch tok.kind {
+		case patternAnyDeep:
+			for j := i; j <= len(tokens); j++ {
+				if matchTokens(pattern[i+1:], tokens[j:]) {
+					return true
+				}
+			}
+
+			return false
+		case patternAny:
+			if i >= len(tokens) {
+				return false
+			}
+		default:
+			if i >= len(tokens) || tokens[i].field != tok.field {
+				return false
+			}
+		}
+	}
+
+	return len(pattern) == len(tokens)
+}
@@ -0,0 +1,71 @@
+package jsonpointer
+
+import "testing"
+
+func TestPatternMatch(t *testing.T) {
+	t.Parallel()
+
+	type test struct {
+		pattern string
+		ptr     string
+		match   bool
+	}
+
+	tests := []test{
+		{"", "", true},
+		{"", "/a", false},
+		{"/a", "/a", true},
+		{"/a", "/b", false},
+		{"/*", "/a", true},
+		{"/*", "", false},
+		{"/*", "/a/b", false},
+		{"/a/*/c", "/a/0/c", true},
+		{"/a/*/c", "/a/0/d", false},
+		{"/**", "", true},
+		{"/**", "/a/b/c", true},
+		{"/**/token", "/token", true},
+		{"/**/token", "/a/b/token", true},
+		{"/**/token", "/a/b/token/c", false},
+		{"/a~1b", "/a~1b", true},
+		{"/a~1b", "/a/b", false},
+		{"/a/**/b/*", "/a/x/b/y/b/z", true},
+		{"/~2", "/*", true},
+		{"/~2", "/a", false},
+		{"/~2~2", "/**", true},
+		{"/~2~2", "/a/b", false},
+		{"/a~2b", "/a*b", true},
+		{"/a*b", "/a*b", true},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePattern(test.pattern)
+		if err != nil {
+			t.Fatalf("ParsePattern(%s) = %v, want <nil>", test.pattern, err)
+		}
+
+		if m := p.Match(MustParse(test.ptr)); m != test.match {
+			t.Errorf("Pattern(%s).Match(%s) = %t, want %t", test.pattern, test.ptr, m, test.match)
+		}
+	}
+}
+
+func TestPatternString(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{"", "/a/*/b", "/**/~0~1", "/~2/~2~2", "/a*b"} {
+		p, err := ParsePattern(pattern)
+		if err != nil {
+			t.Fatalf("ParsePattern(%s) = %v, want <nil>", pattern, err)
+		}
+
+		if s := p.String(); s != pattern {
+			t.Errorf("Pattern(%s).String() = %s, want %s", pattern, s, pattern)
+		}
+	}
+
+	for _, pattern := range []string{"a", "/~3", "/a~"} {
+		if _, err := ParsePattern(pattern); err == nil {
+			t.Errorf("ParsePattern(%s) = <nil>, want error", pattern)
+		}
+	}
+}
@@ -77,47 +77,7 @@ func (p Pointer) AppendText(buf []byte) ([]byte, error) {
 
 	for _, tok := range p.tokens {
 		buf = append(buf, '/')
-
-		i := strings.IndexByte(tok.field, '~')
-		j := strings.IndexByte(tok.field, '/')
-		if i == -1 && j == -1 {
-			buf = append(buf, tok.field...)
-			continue
-		}
-
-		var k int
-		if i != -1 && (j == -1 || i < j) {
-			k = i
-		} else {
-			k = j
-		}
-
-		buf = append(buf, tok.field[:k]...)
-		remaining := tok.field[k+1:]
-		for {
-			if i != -1 {
-				buf = append(buf, '~', '0')
-			} else {
-				buf = append(buf, '~', '1')
-			}
-
-			i = strings.IndexByte(remaining, '~')
-			j = strings.IndexByte(remaining, '/')
-			if i == -1 && j == -1 {
-				buf = append(buf, remaining...)
-				break
-			}
-
-			var k int
-			if i != -1 && i < j {
-				k = i
-			} else {
-				k = j
-			}
-
-			buf = append(buf, remaining[:k]...)
-			remaining = remaining[k+1:]
-		}
+		buf = appendToken(buf, tok.field)
 	}
 
 	return buf, nil
@@ -139,6 +99,17 @@ func (p Pointer) Equal(o Pointer) bool {
 	return true
 }
 
+// Index returns the array index that the reference token at the index i
+// refers to. Index returns -1 if the reference token is not a valid array
+// index or the requested index doesn't exist.
+func (p Pointer) Index(i int) int {
+	if i < 0 || i >= len(p.tokens) {
+		return -1
+	}
+
+	return p.tokens[i].index
+}
+
 // IsZero reports whether the Pointer is the zero value. A zero Pointer value
 // resolves against the root of a value.
 func (p Pointer) IsZero() bool {
@@ -166,47 +137,7 @@ func (p Pointer) String() string {
 
 	for _, tok := range p.tokens {
 		buf = append(buf, '/')
-
-		i := strings.IndexByte(tok.field, '~')
-		j := strings.IndexByte(tok.field, '/')
-		if i == -1 && j == -1 {
-			buf = append(buf, tok.field...)
-			continue
-		}
-
-		var k int
-		if i != -1 && (j == -1 || i < j) {
-			k = i
-		} else {
-			k = j
-		}
-
-		buf = append(buf, tok.field[:k]...)
-		remaining := tok.field[k+1:]
-		for {
-			if i != -1 {
-				buf = append(buf, '~', '0')
-			} else {
-				buf = append(buf, '~', '1')
-			}
-
-			i = strings.IndexByte(remaining, '~')
-			j = strings.IndexByte(remaining, '/')
-			if i == -1 && j == -1 {
-				buf = append(buf, remaining...)
-				break
-			}
-
-			var k int
-			if i != -1 && i < j {
-				k = i
-			} else {
-				k = j
-			}
-
-			buf = append(buf, remaining[:k]...)
-			remaining = remaining[k+1:]
-		}
+		buf = appendToken(buf, tok.field)
 	}
 
 	return unsafe.String(unsafe.SliceData(buf), len(buf))
@@ -248,6 +179,30 @@ func (p Pointer) Trim(count int) Pointer {
 	}
 }
 
+func (p Pointer) child(tok token) Pointer {
+	tokens := make([]token, len(p.tokens)+1)
+	copy(tokens, p.tokens)
+	tokens[len(p.tokens)] = tok
+
+	return Pointer{
+		tokens: tokens,
+	}
+}
+
+func (p Pointer) isPrefixOf(o Pointer) bool {
+	if len(p.tokens) > len(o.tokens) {
+		return false
+	}
+
+	for i, tok := range p.tokens {
+		if tok != o.tokens[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
 func (p *Pointer) UnmarshalText(data []byte) error {
 	if len(data) == 0 {
@@ -22,6 +22,8 @@ func TestParse(t *testing.T) {
 		"/01",
 		"/1",
 		"/a/b/c",
+		"/a~1b~0c",
+		"/~0~1/~1~0",
 	}
 
 	for _, ptr := range ptrs {
@@ -59,6 +61,8 @@ func TestPointerEqual(t *testing.T) {
 		"/01",
 		"/1",
 		"/a/b/c",
+		"/a~1b~0c",
+		"/~0~1/~1~0",
 	}
 
 	var prev Pointer
@@ -82,6 +86,21 @@ func TestPointerEqual(t *testing.T) {
 	}
 }
 
+func TestPointerIndex(t *testing.T) {
+	t.Parallel()
+
+	p := MustParse("/0/12/01/-/a/")
+	for i, want := range []int{0, 12, -1, -1, -1, -1, -1} {
+		if index := p.Index(i); index != want {
+			t.Errorf("Pointer.Index(%d) = %d, want %d", i, index, want)
+		}
+	}
+
+	if index := p.Index(-1); index != -1 {
+		t.Errorf("Pointer.Index(-1) = %d, want -1", index)
+	}
+}
+
 func TestPointerMarshalText(t *testing.T) {
 	t.Parallel()
 
@@ -97,6 +116,8 @@ func TestPointerMarshalText(t *testing.T) {
 		[]byte("/01"),
 		[]byte("/1"),
 		[]byte("/a/b/c"),
+		[]byte("/a~1b~0c"),
+		[]byte("/~0~1/~1~0"),
 	}
 
 	for _, ptr := range ptrs {
@@ -140,6 +161,8 @@ func TestPointerString(t *testing.T) {
 		"/01",
 		"/1",
 		"/a/b/c",
+		"/a~1b~0c",
+		"/~0~1/~1~0",
 	}
 
 	for _, ptr := range ptrs {
@@ -0,0 +1,140 @@
+package jsonpointer
+
+import (
+	"reflect"
+)
+
+// PointerTo returns the JSON pointer of the value that field points to within
+// the value that root points to, for example PointerTo(&cfg,
+// &cfg.DB.Hosts[2].Port). Struct fields are named as they are by
+// [Pointer.Get]. PointerTo returns an error matching [ErrValueNotFound] if
+// field does not point to a value within root.
+func PointerTo(root any, field any) (Pointer, error) {
+	target := reflect.ValueOf(field)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return Pointer{}, &unsupportedValueError{reflect.TypeOf(field)}
+	}
+
+	f := pointerFinder{
+		addr:    target.Pointer(),
+		t:       target.Type().Elem(),
+		visited: make(map[visit]struct{}),
+	}
+
+	if f.find(reflect.ValueOf(root)) {
+		return Pointer{
+			tokens: f.tokens,
+		}, nil
+	}
+
+	return Pointer{}, &fieldNotFoundError{target.Type()}
+}
+
+type pointerFinder struct {
+	addr    uintptr
+	t       reflect.Type
+	tokens  []token
+	visited map[visit]struct{}
+}
+
+// visit identifies a pointer, slice or map that has already been searched. A
+// struct and its first field share an address, so the type is part of the
+// key, and slices of the same array differ by their length.
+type visit struct {
+	p uintptr
+	t reflect.Type
+	n int
+}
+
+// enter records value as visited and reports whether it had not been visited
+// before.
+func (f *pointerFinder) enter(value reflect.Value) bool {
+	v := visit{
+		p: value.Pointer(),
+		t: value.Type(),
+	}
+
+	if value.Kind() == reflect.Slice {
+		v.n = value.Len()
+	}
+
+	if _, ok := f.visited[v]; ok {
+		return false
+	}
+
+	f.visited[v] = struct{}{}
+	return true
+}
+
+// find reports whether the target is value, or is reachable from value, and
+// records the reference tokens that lead to it.
+func (f *pointerFinder) find(value reflect.Value) bool {
+	if value.CanAddr() && value.Addr().Pointer() == f.addr && value.Type() == f.t {
+		return true
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return false
+		}
+
+		return f.find(value.Elem())
+	case reflect.Pointer:
+		if value.IsNil() {
+			return false
+		}
+
+		if !f.enter(value) {
+			return false
+		}
+
+		return f.find(value.Elem())
+	case reflect.Struct:
+		for name, index := range getStructFields(value.Type()) {
+			field, err := value.FieldByIndexErr(index)
+			if err != nil {
+				continue
+			}
+
+			if f.descend(makeToken(name), field) {
+				return true
+			}
+		}
+	case reflect.Slice:
+		if value.IsNil() || !f.enter(value) {
+			return false
+		}
+
+		fallthrough
+	case reflect.Array:
+		for i := range value.Len() {
+			if f.descend(indexToken(i), value.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() || !f.enter(value) {
+			return false
+		}
+
+		iter := value.MapRange()
+		for iter.Next() {
+			if f.descend(makeToken(mapKeyString(iter.Key())), iter.Value()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (f *pointerFinder) descend(tok token, value reflect.Value) bool {
+	f.tokens = append(f.tokens, tok)
+	if f.find(value) {
+		return true
+	}
+
+	f.tokens = f.tokens[:len(f.tokens)-1]
+	return false
+}
@@ -0,0 +1,111 @@
+package jsonpointer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPointerTo(t *testing.T) {
+	t.Parallel()
+
+	type Host struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+
+	type Options struct {
+		Timeout int `json:"timeout"`
+	}
+
+	type DB struct {
+		*Options
+		Hosts []Host           `json:"hosts"`
+		Tags  map[string]*Host `json:"tags"`
+		Pair  [2]Host          `json:"pair"`
+	}
+
+	type Config struct {
+		DB   DB `json:"db"`
+		Self *Config
+	}
+
+	primary := &Host{}
+	cfg := &Config{
+		DB: DB{
+			Options: &Options{},
+			Hosts:   make([]Host, 3),
+			Tags: map[string]*Host{
+				"a/b": primary,
+			},
+		},
+	}
+
+	cfg.Self = cfg
+
+	type test struct {
+		field any
+		want  string
+	}
+
+	tests := []test{
+		{cfg, ""},
+		{&cfg.DB, "/db"},
+		{&cfg.DB.Hosts[2].Port, "/db/hosts/2/port"},
+		{&cfg.DB.Hosts[0], "/db/hosts/0"},
+		{&cfg.DB.Timeout, "/db/timeout"},
+		{&primary.Name, "/db/tags/a~1b/name"},
+		{&cfg.DB.Pair[1].Name, "/db/pair/1/name"},
+	}
+
+	for _, test := range tests {
+		p, err := PointerTo(cfg, test.field)
+		if err != nil || p.String() != test.want {
+			t.Errorf("PointerTo(%T) = (%s, %v), want (%s, <nil>)", test.field, p, err, test.want)
+		}
+	}
+
+	other := 0
+	_, err := PointerTo(cfg, &other)
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("PointerTo() = %v, want %v", err, ErrValueNotFound)
+	}
+
+	_, err = PointerTo(cfg, other)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("PointerTo() = %v, want %v", err, ErrUnsupportedValue)
+	}
+
+	type Outer struct {
+		Host Host `json:"host"`
+		Port int  `json:"port"`
+	}
+
+	type Root struct {
+		Host  *Host  `json:"host"`
+		Outer *Outer `json:"outer"`
+	}
+
+	outer := &Outer{}
+	root := &Root{
+		Host:  &outer.Host,
+		Outer: outer,
+	}
+
+	p, err := PointerTo(root, &outer.Port)
+	if err != nil || p.String() != "/outer/port" {
+		t.Errorf("PointerTo() = (%s, %v), want (/outer/port, <nil>)", p, err)
+	}
+
+	list := make([]any, 1)
+	list[0] = list
+
+	set := map[string]any{}
+	set["self"] = set
+
+	for _, root := range []any{&list, &set} {
+		_, err = PointerTo(root, &other)
+		if !errors.Is(err, ErrValueNotFound) {
+			t.Errorf("PointerTo(%T) = %v, want %v", root, err, ErrValueNotFound)
+		}
+	}
+}
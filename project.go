@@ -0,0 +1,152 @@
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// ProjectOptions configures the behaviour of [ProjectOptions.Project].
+type ProjectOptions struct {
+	// CompactArrays causes arrays in the projected document to contain only
+	// the selected elements, in their original order. By default selected
+	// elements keep their original index and unselected elements before them
+	// are set to nil.
+	CompactArrays bool
+}
+
+// Project is like [ProjectOptions.Project] using the default options.
+func Project(doc any, ptrs ...Pointer) (any, error) {
+	return ProjectOptions{}.Project(doc, ptrs...)
+}
+
+// Project returns a new document containing only the values in doc that are
+// selected by ptrs. Objects along the selected paths are created as
+// map[string]any values and arrays as []any values, whether doc is a generic
+// tree or a Go struct. Selected values themselves are included as they are,
+// without being copied. Pointers that do not resolve to a value in doc,
+// including those that use a member name to index an array, are ignored, and
+// objects and arrays in which no selected value resolves are left out.
+func (o ProjectOptions) Project(doc any, ptrs ...Pointer) (any, error) {
+	var t Trie
+	for _, p := range ptrs {
+		t.Add(p)
+	}
+
+	result, _, err := o.project(&t.root, doc)
+	return result, err
+}
+
+// project returns the values selected by n within value, and whether any of
+// them resolved.
+func (o ProjectOptions) project(n *trieNode, value any) (any, bool, error) {
+	if n.present {
+		return value, true, nil
+	}
+
+	if !isArray(value) {
+		result := make(map[string]any, len(n.children))
+		for field, child := range n.children {
+			v, err := getToken(child.tok, value)
+			if err != nil {
+				if isMissing(err) {
+					continue
+				}
+
+				return nil, false, err
+			}
+
+			v, ok, err := o.project(child, v)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if ok {
+				result[field] = v
+			}
+		}
+
+		return result, len(result) > 0, nil
+	}
+
+	children := slices.SortedFunc(maps.Values(n.children), func(a, b *trieNode) int {
+		return a.tok.index - b.tok.index
+	})
+
+	var result []any
+	for _, child := range children {
+		v, err := getToken(child.tok, value)
+		if err != nil {
+			if isMissing(err) {
+				continue
+			}
+
+			return nil, false, err
+		}
+
+		v, ok, err := o.project(child, v)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if !o.CompactArrays {
+			result = append(result, make([]any, child.tok.index-len(result))...)
+		}
+
+		result = append(result, v)
+	}
+
+	if result == nil {
+		return []any{}, false, nil
+	}
+
+	return result, true, nil
+}
+
+func isArray(value any) bool {
+	switch v := value.(type) {
+	case []any:
+		return true
+	case map[string]any:
+		return false
+	case json.RawMessage:
+		return isRawArray(v)
+	}
+
+	refValue := reflect.ValueOf(value)
+	for refValue.Kind() == reflect.Interface || refValue.Kind() == reflect.Pointer {
+		if refValue.IsNil() {
+			return false
+		}
+
+		refValue = refValue.Elem()
+	}
+
+	if !refValue.IsValid() {
+		return false
+	}
+
+	if refValue.Type() == rawMessageType {
+		return isRawArray(refValue.Bytes())
+	}
+
+	k := refValue.Kind()
+	return k == reflect.Array || k == reflect.Slice
+}
+
+func isRawArray(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '['
+}
+
+func isMissing(err error) bool {
+	return errors.Is(err, ErrValueNotFound) || errors.Is(err, ErrArrayIndexOutOfBounds) ||
+		errors.Is(err, ErrInvalidArrayIndex)
+}
@@ -0,0 +1,117 @@
+package jsonpointer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProject(t *testing.T) {
+	t.Parallel()
+
+	var value any = map[string]any{
+		"a": map[string]any{
+			"b": "c",
+			"d": "e",
+		},
+		"f": []any{
+			"g",
+			map[string]any{
+				"h": "i",
+				"j": "k",
+			},
+			"l",
+		},
+		"m": "n",
+	}
+
+	result, err := Project(value, MustParse("/a/b"), MustParse("/f/1/h"), MustParse("/m"), MustParse("/x"))
+	if err != nil {
+		t.Fatalf("Project() = %v, want <nil>", err)
+	}
+
+	want := map[string]any{
+		"a": map[string]any{
+			"b": "c",
+		},
+		"f": []any{
+			nil,
+			map[string]any{
+				"h": "i",
+			},
+		},
+		"m": "n",
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Project() = %v, want %v", result, want)
+	}
+
+	result, err = ProjectOptions{CompactArrays: true}.Project(value, MustParse("/f/2"), MustParse("/f/1/j"))
+	if err != nil {
+		t.Fatalf("ProjectOptions.Project() = %v, want <nil>", err)
+	}
+
+	want = map[string]any{
+		"f": []any{
+			map[string]any{
+				"j": "k",
+			},
+			"l",
+		},
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("ProjectOptions.Project() = %v, want %v", result, want)
+	}
+
+	result, err = Project(value, MustParse("/a/x/y"), MustParse("/f/x"), MustParse("/f/1/x"), MustParse("/m"))
+	if err != nil {
+		t.Fatalf("Project() = %v, want <nil>", err)
+	}
+
+	want = map[string]any{
+		"m": "n",
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Project() = %v, want %v", result, want)
+	}
+
+	type B struct {
+		B string `json:"b"`
+		C string `json:"c"`
+	}
+
+	type A struct {
+		A []B `json:"a"`
+		D int `json:"d"`
+	}
+
+	value = &A{
+		A: []B{
+			{
+				B: "b",
+				C: "c",
+			},
+		},
+		D: 1,
+	}
+
+	result, err = Project(value, MustParse("/a/0/c"), MustParse("/d"))
+	if err != nil {
+		t.Fatalf("Project() = %v, want <nil>", err)
+	}
+
+	want = map[string]any{
+		"a": []any{
+			map[string]any{
+				"c": "c",
+			},
+		},
+		"d": 1,
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Project() = %v, want %v", result, want)
+	}
+}
@@ -0,0 +1,152 @@
+package jsonpointer
+
+import (
+	"reflect"
+)
+
+// Query holds a set of JSON pointers that are evaluated against a value
+// together. Pointers sharing a common prefix are stored in a trie, so each
+// shared reference token is resolved once per evaluation rather than once per
+// pointer.
+type Query struct {
+	root queryNode
+	ptrs []Pointer
+}
+
+type queryNode struct {
+	tok      token
+	children []*queryNode
+	ptrs     []int
+}
+
+// QueryResult holds the result of evaluating a single JSON pointer as part of
+// a [Query].
+type QueryResult struct {
+	Pointer Pointer
+	Value   any
+	Err     error
+}
+
+// NewQuery returns a Query that evaluates the JSON pointers ptrs.
+func NewQuery(ptrs ...Pointer) *Query {
+	q := &Query{}
+	for _, p := range ptrs {
+		q.Add(p)
+	}
+
+	return q
+}
+
+// Add adds the JSON pointer p to the Query.
+func (q *Query) Add(p Pointer) {
+	n := &q.root
+	for _, tok := range p.tokens {
+		var child *queryNode
+		for _, c := range n.children {
+			if c.tok.field == tok.field {
+				child = c
+				break
+			}
+		}
+
+		if child == nil {
+			child = &queryNode{
+				tok: tok,
+			}
+
+			n.children = append(n.children, child)
+		}
+
+		n = child
+	}
+
+	n.ptrs = append(n.ptrs, len(q.ptrs))
+	q.ptrs = append(q.ptrs, p)
+}
+
+// Len returns the number of JSON pointers in the Query.
+func (q *Query) Len() int {
+	return len(q.ptrs)
+}
+
+// Eval resolves every JSON pointer in the Query against value. The results are
+// returned in the order the pointers were added to the Query. A pointer that
+// cannot be resolved has its error recorded in the Err field of its result,
+// and does not prevent the other pointers from being resolved.
+func (q *Query) Eval(value any, opts ...GetOption) []QueryResult {
+	results := make([]QueryResult, len(q.ptrs))
+	for i, p := range q.ptrs {
+		results[i].Pointer = p
+	}
+
+	q.root.eval(value, 0, results, opts)
+	return results
+}
+
+func (n *queryNode) eval(value any, depth int, results []QueryResult, opts []GetOption) {
+	for _, i := range n.ptrs {
+		results[i].Value = value
+	}
+
+	for _, c := range n.children {
+		result, ok, err := get(c.tok, value)
+		if err != nil {
+			c.fail(err, results)
+			continue
+		}
+
+		if ok {
+			c.eval(result, depth+1, results, opts)
+			continue
+		}
+
+		c.evalReflect(reflect.ValueOf(value), depth, results, opts)
+	}
+}
+
+func (n *queryNode) evalReflect(value reflect.Value, depth int, results []QueryResult, opts []GetOption) {
+	if err := getReflect(n.tok, &value); err != nil {
+		if err != errRawMessage {
+			n.fail(err, results)
+			return
+		}
+
+		n.evalRawMessage(value.Bytes(), depth, results, opts)
+		return
+	}
+
+	if len(n.ptrs) > 0 {
+		result := value.Interface()
+		for _, i := range n.ptrs {
+			results[i].Value = result
+		}
+	}
+
+	for _, c := range n.children {
+		c.evalReflect(value, depth+1, results, opts)
+	}
+}
+
+func (n *queryNode) evalRawMessage(data []byte, depth int, results []QueryResult, opts []GetOption) {
+	n.walk(func(m *queryNode) {
+		for _, i := range m.ptrs {
+			tokens := results[i].Pointer.tokens[depth:]
+			results[i].Value, results[i].Err = getRawMessage(tokens, data, opts)
+		}
+	})
+}
+
+func (n *queryNode) fail(err error, results []QueryResult) {
+	n.walk(func(m *queryNode) {
+		for _, i := range m.ptrs {
+			results[i].Err = err
+		}
+	})
+}
+
+func (n *queryNode) walk(f func(*queryNode)) {
+	f(n)
+	for _, c := range n.children {
+		c.walk(f)
+	}
+}
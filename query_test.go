@@ -0,0 +1,129 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestQueryEval(t *testing.T) {
+	t.Parallel()
+
+	type B struct {
+		B string
+		C int
+	}
+
+	type A struct {
+		A    []B
+		D    map[string]any
+		Body json.RawMessage
+	}
+
+	value := &A{
+		A: []B{
+			{},
+			{
+				B: "C",
+				C: 1,
+			},
+		},
+		D: map[string]any{
+			"E": []any{"F"},
+		},
+		Body: json.RawMessage(`{"G": "H"}`),
+	}
+
+	q := NewQuery(
+		MustParse("/A/1/B"),
+		MustParse("/A/1/C"),
+		MustParse("/A/2/B"),
+		MustParse("/D/E/0"),
+		MustParse("/D/X"),
+		MustParse("/Body/G"),
+		MustParse("/A/1/B"),
+	)
+
+	if q.Len() != 7 {
+		t.Fatalf("Query.Len() = %d, want 7", q.Len())
+	}
+
+	results := q.Eval(value)
+	if len(results) != 7 {
+		t.Fatalf("len(Query.Eval()) = %d, want 7", len(results))
+	}
+
+	type want struct {
+		value any
+		err   error
+	}
+
+	wants := []want{
+		{"C", nil},
+		{1, nil},
+		{nil, ErrArrayIndexOutOfBounds},
+		{"F", nil},
+		{nil, ErrValueNotFound},
+		{"H", nil},
+		{"C", nil},
+	}
+
+	for i, w := range wants {
+		r := results[i]
+		if !r.Pointer.Equal(q.ptrs[i]) {
+			t.Errorf("Query.Eval()[%d].Pointer = %s, want %s", i, r.Pointer, q.ptrs[i])
+		}
+
+		if w.err != nil {
+			if !errors.Is(r.Err, w.err) {
+				t.Errorf("Query.Eval()[%d].Err = %v, want %v", i, r.Err, w.err)
+			}
+
+			continue
+		}
+
+		if r.Value != w.value || r.Err != nil {
+			t.Errorf("Query.Eval()[%d] = (%v, %v), want (%v, <nil>)", i, r.Value, r.Err, w.value)
+		}
+	}
+}
+
+func BenchmarkQueryEval(b *testing.B) {
+	b.ReportAllocs()
+
+	type C struct {
+		C string
+		D string
+	}
+
+	type B struct {
+		B C
+	}
+
+	type A struct {
+		A []B
+	}
+
+	value := &A{
+		A: []B{
+			{},
+			{},
+			{
+				B: C{
+					C: "D",
+					D: "E",
+				},
+			},
+		},
+	}
+
+	q := NewQuery(MustParse("/A/2/B/C"), MustParse("/A/2/B/D"))
+
+	for b.Loop() {
+		for _, r := range q.Eval(value) {
+			if r.Err != nil {
+				b.Fatalf("Query.Eval() = %v, want <nil>", r.Err)
+			}
+		}
+	}
+}
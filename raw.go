@@ -0,0 +1,108 @@
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// errRawMessage is returned by getReflect when it encounters a json.RawMessage
+// value that must be resolved against its encoded JSON instead.
+var errRawMessage = errors.New("jsonpointer: raw message")
+
+// getRawMessage resolves tokens against the encoded JSON value data. An empty
+// value is treated as missing, and data must otherwise be valid JSON with
+// nothing following the value.
+func getRawMessage(tokens []token, data []byte, opts []GetOption) (any, error) {
+	var o getOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		var field string
+		if len(tokens) > 0 {
+			field = tokens[0].field
+		}
+
+		return nil, &valueNotFoundError{field}
+	}
+
+	if !json.Valid(data) {
+		return nil, json.Unmarshal(data, new(json.RawMessage))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for _, tok := range tokens {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t {
+		case json.Delim('{'):
+			var found bool
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				if key == tok.field {
+					found = true
+					break
+				}
+
+				if err := skipRawValue(dec); err != nil {
+					return nil, err
+				}
+			}
+
+			if !found {
+				return nil, &valueNotFoundError{tok.field}
+			}
+		case json.Delim('['):
+			if tok.index == -1 && tok.field != "-" {
+				return nil, &invalidArrayIndexError{tok.field}
+			}
+
+			var n int
+			for ; (tok.index == -1 || n < tok.index) && dec.More(); n++ {
+				if err := skipRawValue(dec); err != nil {
+					return nil, err
+				}
+			}
+
+			if tok.index == -1 {
+				return nil, &arrayIndexOutOfBoundsError{n}
+			}
+
+			if !dec.More() {
+				return nil, &arrayIndexOutOfBoundsError{tok.index}
+			}
+		default:
+			return nil, &valueNotFoundError{tok.field}
+		}
+	}
+
+	if o.raw {
+		var result json.RawMessage
+		if err := dec.Decode(&result); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	var result any
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func skipRawValue(dec *json.Decoder) error {
+	var skip json.RawMessage
+	return dec.Decode(&skip)
+}
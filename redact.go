@@ -0,0 +1,288 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// RedactOptions configures the behaviour of [RedactOptions.Redact].
+type RedactOptions struct {
+	// Replacement is the value that matching locations are replaced with. If
+	// Replacement cannot be assigned to a matching location in a Go value,
+	// the location is set to its zero value instead.
+	Replacement any
+
+	// Remove causes matching members of objects and elements of slices to be
+	// removed, rather than replaced. Matching struct fields and array
+	// elements are set to their zero value.
+	Remove bool
+}
+
+// Redact is like [RedactOptions.Redact] with the Replacement option set to
+// replacement.
+func Redact(doc any, replacement any, patterns ...Pattern) (any, error) {
+	return RedactOptions{Replacement: replacement}.Redact(doc, patterns...)
+}
+
+// Redact returns a deep copy of doc in which every location matching any of
+// patterns has been replaced or removed. Generic trees are copied as generic
+// trees and Go values keep their types, with struct fields matched using
+// their JSON names. Values held in a [json.RawMessage] are decoded, redacted
+// and encoded again. doc itself is not modified.
+func (o RedactOptions) Redact(doc any, patterns ...Pattern) (any, error) {
+	r := redactor{
+		opts:     o,
+		patterns: patterns,
+	}
+
+	if r.match() {
+		if o.Remove {
+			return nil, nil
+		}
+
+		return o.Replacement, nil
+	}
+
+	if doc == nil {
+		return nil, nil
+	}
+
+	v, err := r.copy(reflect.ValueOf(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Interface(), nil
+}
+
+type redactor struct {
+	opts     RedactOptions
+	patterns []Pattern
+	path     []token
+}
+
+func (r *redactor) match() bool {
+	for _, p := range r.patterns {
+		if matchTokens(p.tokens, r.path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *redactor) replacement(t reflect.Type) reflect.Value {
+	v := reflect.ValueOf(r.opts.Replacement)
+	if r.opts.Remove || !v.IsValid() || !v.Type().AssignableTo(t) {
+		return reflect.Zero(t)
+	}
+
+	result := reflect.New(t).Elem()
+	result.Set(v)
+	return result
+}
+
+func (r *redactor) push(field string) {
+	r.path = append(r.path, makeToken(field))
+}
+
+func (r *redactor) pop() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *redactor) copy(value reflect.Value) (reflect.Value, error) {
+	t := value.Type()
+	if t == rawMessageType {
+		return r.copyRawMessage(value)
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		result := reflect.New(t).Elem()
+		if value.IsNil() {
+			return result, nil
+		}
+
+		elem, err := r.copy(value.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.Set(elem)
+		return result, nil
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value, nil
+		}
+
+		elem, err := r.copy(value.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result := reflect.New(t.Elem())
+		result.Elem().Set(elem)
+		return result, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return value, nil
+		}
+
+		result := reflect.MakeMapWithSize(t, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			r.push(mapKeyString(iter.Key()))
+			if r.match() {
+				if !r.opts.Remove {
+					result.SetMapIndex(iter.Key(), r.replacement(t.Elem()))
+				}
+
+				r.pop()
+				continue
+			}
+
+			elem, err := r.copy(iter.Value())
+			r.pop()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			result.SetMapIndex(iter.Key(), elem)
+		}
+
+		return result, nil
+	case reflect.Slice:
+		if value.IsNil() {
+			return value, nil
+		}
+
+		result := reflect.MakeSlice(t, 0, value.Len())
+		for i := range value.Len() {
+			r.push(strconv.Itoa(i))
+			if r.match() {
+				if !r.opts.Remove {
+					result = reflect.Append(result, r.replacement(t.Elem()))
+				}
+
+				r.pop()
+				continue
+			}
+
+			elem, err := r.copy(value.Index(i))
+			r.pop()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			result = reflect.Append(result, elem)
+		}
+
+		return result, nil
+	case reflect.Array:
+		result := reflect.New(t).Elem()
+		for i := range value.Len() {
+			r.push(strconv.Itoa(i))
+			if r.match() {
+				result.Index(i).Set(r.replacement(t.Elem()))
+				r.pop()
+				continue
+			}
+
+			elem, err := r.copy(value.Index(i))
+			r.pop()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			result.Index(i).Set(elem)
+		}
+
+		return result, nil
+	case reflect.Struct:
+		result := reflect.New(t).Elem()
+		result.Set(value)
+		copyEmbedded(result)
+
+		for name, index := range getStructFields(t) {
+			field, err := result.FieldByIndexErr(index)
+			if err != nil || !field.CanSet() {
+				continue
+			}
+
+			r.push(name)
+			if r.match() {
+				field.Set(r.replacement(field.Type()))
+				r.pop()
+				continue
+			}
+
+			elem, err := r.copy(field)
+			r.pop()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			field.Set(elem)
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+func (r *redactor) copyRawMessage(value reflect.Value) (reflect.Value, error) {
+	if value.IsNil() {
+		return value, nil
+	}
+
+	if !json.Valid(value.Bytes()) {
+		return reflect.Value{}, json.Unmarshal(value.Bytes(), new(json.RawMessage))
+	}
+
+	doc, err := decodeGeneric(value.Bytes())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	v, err := r.copy(reflect.ValueOf(&doc).Elem())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return reflect.ValueOf(data).Convert(value.Type()), nil
+}
+
+// copyEmbedded replaces the embedded struct pointers of value with pointers to
+// shallow copies, so that promoted fields can be set without modifying the
+// original value.
+func copyEmbedded(value reflect.Value) {
+	t := value.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.Anonymous {
+			continue
+		}
+
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Pointer:
+			if field.IsNil() || field.Elem().Kind() != reflect.Struct || !field.CanSet() {
+				continue
+			}
+
+			elem := reflect.New(field.Type().Elem())
+			elem.Elem().Set(field.Elem())
+			field.Set(elem)
+			copyEmbedded(elem.Elem())
+		case reflect.Struct:
+			copyEmbedded(field)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	patterns := []Pattern{
+		MustParsePattern("/password"),
+		MustParsePattern("/**/token"),
+		MustParsePattern("/cards/*/number"),
+	}
+
+	var value any = map[string]any{
+		"password": "a",
+		"session": map[string]any{
+			"token": "b",
+			"user":  "c",
+		},
+		"cards": []any{
+			map[string]any{
+				"number": "d",
+				"expiry": "e",
+			},
+		},
+	}
+
+	result, err := Redact(value, "***", patterns...)
+	if err != nil {
+		t.Fatalf("Redact() = %v, want <nil>", err)
+	}
+
+	want := map[string]any{
+		"password": "***",
+		"session": map[string]any{
+			"token": "***",
+			"user":  "c",
+		},
+		"cards": []any{
+			map[string]any{
+				"number": "***",
+				"expiry": "e",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Redact() = %v, want %v", result, want)
+	}
+
+	if value.(map[string]any)["password"] != "a" {
+		t.Errorf("Redact() modified its input")
+	}
+
+	result, err = RedactOptions{Remove: true}.Redact(value, patterns...)
+	if err != nil {
+		t.Fatalf("RedactOptions.Redact() = %v, want <nil>", err)
+	}
+
+	want = map[string]any{
+		"session": map[string]any{
+			"user": "c",
+		},
+		"cards": []any{
+			map[string]any{
+				"expiry": "e",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("RedactOptions.Redact() = %v, want %v", result, want)
+	}
+
+	type Card struct {
+		Number string `json:"number"`
+		CVC    int    `json:"cvc"`
+	}
+
+	type Session struct {
+		Token string `json:"token"`
+	}
+
+	type User struct {
+		*Session
+		Password string          `json:"password"`
+		Cards    []Card          `json:"cards"`
+		Extra    json.RawMessage `json:"extra"`
+	}
+
+	user := &User{
+		Session: &Session{
+			Token: "a",
+		},
+		Password: "b",
+		Cards: []Card{
+			{
+				Number: "c",
+				CVC:    1,
+			},
+		},
+		Extra: json.RawMessage(`{"id":12345678901234567891,"token":"d"}`),
+	}
+
+	result, err = Redact(user, "***", append(patterns, MustParsePattern("/cards/*/cvc"))...)
+	if err != nil {
+		t.Fatalf("Redact() = %v, want <nil>", err)
+	}
+
+	redacted, ok := result.(*User)
+	if !ok {
+		t.Fatalf("Redact() = %T, want *User", result)
+	}
+
+	if redacted.Token != "***" || redacted.Password != "***" || redacted.Cards[0].Number != "***" || redacted.Cards[0].CVC != 0 {
+		t.Errorf("Redact() = %+v, want redacted fields", redacted)
+	}
+
+	if want := `{"id":12345678901234567891,"token":"***"}`; string(redacted.Extra) != want {
+		t.Errorf("Redact().Extra = %s, want %s", redacted.Extra, want)
+	}
+
+	if user.Token != "a" || user.Password != "b" || user.Cards[0].Number != "c" || user.Cards[0].CVC != 1 {
+		t.Errorf("Redact() modified its input")
+	}
+}
@@ -0,0 +1,175 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// Loader loads the JSON document identified by an absolute URI, without a
+// fragment.
+type Loader interface {
+	Load(uri *url.URL) (any, error)
+}
+
+// LoaderFunc is an adapter to allow the use of ordinary functions as a
+// [Loader].
+type LoaderFunc func(uri *url.URL) (any, error)
+
+// Load calls f(uri).
+func (f LoaderFunc) Load(uri *url.URL) (any, error) {
+	return f(uri)
+}
+
+// FSLoader returns a [Loader] that reads JSON documents from fsys. The path of
+// each URI, without its leading slash, is used as the name of the file to
+// read. URIs with a scheme other than "file" cannot be loaded.
+func FSLoader(fsys fs.FS) Loader {
+	return LoaderFunc(func(uri *url.URL) (any, error) {
+		if uri.Scheme != "" && uri.Scheme != "file" {
+			return nil, errors.ErrUnsupported
+		}
+
+		data, err := fs.ReadFile(fsys, strings.TrimPrefix(uri.Path, "/"))
+		if err != nil {
+			return nil, err
+		}
+
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+
+		return doc, nil
+	})
+}
+
+// Resolver resolves JSON References, as described by the JSON Reference
+// draft, of the form {"$ref": "other.json#/definitions/x"}. The fragment of a
+// reference is evaluated as a JSON pointer against the referenced document.
+// Documents are loaded at most once and cached by the Resolver.
+type Resolver struct {
+	loader Loader
+	docs   map[string]any
+}
+
+// NewResolver returns a Resolver that loads documents using loader.
+func NewResolver(loader Loader) *Resolver {
+	return &Resolver{
+		loader: loader,
+		docs:   make(map[string]any),
+	}
+}
+
+// AddDocument adds doc to the documents known by the Resolver as uri, so that
+// references to uri are resolved without calling the Loader.
+func (r *Resolver) AddDocument(uri string, doc any) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+	r.docs[u.String()] = doc
+	return nil
+}
+
+// Resolve resolves the reference ref relative to the URI base, and returns the
+// referenced value along with its absolute URI. If the referenced value is
+// itself a reference, it is resolved relative to the document containing it,
+// until a value that isn't a reference is found. Resolve returns an error
+// matching [ErrReferenceCycle] if a chain of references refers back to
+// itself.
+func (r *Resolver) Resolve(base, ref string) (any, string, error) {
+	baseURI, err := url.Parse(base)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var chain []string
+	for {
+		refURI, err := url.Parse(ref)
+		if err != nil {
+			return nil, "", err
+		}
+
+		uri := baseURI.ResolveReference(refURI)
+		key := uri.String()
+		for _, prev := range chain {
+			if prev == key {
+				return nil, "", &referenceCycleError{append(chain, key)}
+			}
+		}
+
+		chain = append(chain, key)
+
+		value, err := r.resolve(uri)
+		if err != nil {
+			return nil, "", err
+		}
+
+		next, ok := refOf(value)
+		if !ok {
+			return value, key, nil
+		}
+
+		baseURI = uri
+		ref = next
+	}
+}
+
+func (r *Resolver) resolve(uri *url.URL) (any, error) {
+	docURI := *uri
+	docURI.Fragment = ""
+	docURI.RawFragment = ""
+
+	doc, err := r.load(&docURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := Parse(uri.Fragment)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.IsZero() {
+		return doc, nil
+	}
+
+	return p.Get(doc)
+}
+
+func (r *Resolver) load(uri *url.URL) (any, error) {
+	key := uri.String()
+	if doc, ok := r.docs[key]; ok {
+		return doc, nil
+	}
+
+	if r.loader == nil {
+		return nil, &loadError{key, fs.ErrNotExist}
+	}
+
+	doc, err := r.loader.Load(uri)
+	if err != nil {
+		return nil, &loadError{key, err}
+	}
+
+	r.docs[key] = doc
+	return doc, nil
+}
+
+// refOf returns the reference held by value if value is a JSON Reference
+// object.
+func refOf(value any) (string, bool) {
+	ref, err := getToken(makeToken("$ref"), value)
+	if err != nil {
+		return "", false
+	}
+
+	s, ok := ref.(string)
+	return s, ok
+}
@@ -0,0 +1,63 @@
+package jsonpointer
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResolverResolve(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"api/openapi.json": {
+			Data: []byte(`{"paths": {"/pets": {"$ref": "paths/pets.json"}}, "self": {"$ref": "#/self"}}`),
+		},
+		"api/paths/pets.json": {
+			Data: []byte(`{"get": {"schema": {"$ref": "../schemas.json#/definitions/Pet"}}}`),
+		},
+		"api/schemas.json": {
+			Data: []byte(`{"definitions": {"Pet": {"$ref": "#/definitions/Animal"}, "Animal": {"type": "object"}, "a/b": 1}}`),
+		},
+	}
+
+	r := NewResolver(FSLoader(fsys))
+
+	value, uri, err := r.Resolve("file:///api/openapi.json", "paths/pets.json#/get/schema")
+	if err != nil {
+		t.Fatalf("Resolver.Resolve() = %v, want <nil>", err)
+	}
+
+	if uri != "file:///api/schemas.json#/definitions/Animal" {
+		t.Errorf("Resolver.Resolve() uri = %s, want file:///api/schemas.json#/definitions/Animal", uri)
+	}
+
+	if m, ok := value.(map[string]any); !ok || m["type"] != "object" {
+		t.Errorf("Resolver.Resolve() = %v, want map[type:object]", value)
+	}
+
+	value, _, err = r.Resolve("file:///api/openapi.json", "schemas.json#/definitions/a~1b")
+	if value != 1.0 || err != nil {
+		t.Errorf("Resolver.Resolve() = (%v, %v), want (1, <nil>)", value, err)
+	}
+
+	_, _, err = r.Resolve("file:///api/openapi.json", "#/self")
+	if !errors.Is(err, ErrReferenceCycle) {
+		t.Errorf("Resolver.Resolve() = %v, want %v", err, ErrReferenceCycle)
+	}
+
+	_, _, err = r.Resolve("file:///api/openapi.json", "missing.json")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Resolver.Resolve() = %v, want %v", err, fs.ErrNotExist)
+	}
+
+	if err := r.AddDocument("https://example.com/a.json", map[string]any{"b": "c"}); err != nil {
+		t.Fatalf("Resolver.AddDocument() = %v, want <nil>", err)
+	}
+
+	value, _, err = r.Resolve("https://example.com/", "a.json#/b")
+	if value != "c" || err != nil {
+		t.Errorf("Resolver.Resolve() = (%v, %v), want (c, <nil>)", value, err)
+	}
+}
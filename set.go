@@ -0,0 +1,522 @@
+package jsonpointer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Setter is implemented by types that set the values referred to by JSON
+// pointers within themselves, such as those generated by the jsonpointer-gen
+// command. [Pointer.Set] calls SetPointer instead of using reflection when
+// doc implements Setter.
+type Setter interface {
+	SetPointer(p Pointer, value any) error
+}
+
+// Set sets the value referred to by the JSON pointer parsed into p within doc
+// to value. doc must be a pointer, or a map or slice whose contents are
+// modified in place. If the last reference token of p refers to a member of
+// an object that does not exist, the member is added, and if it is "-" or
+// the length of a slice, value is appended to the slice, as with the "add"
+// operation of RFC 6902. Set returns an error if any other part of p does not
+// exist.
+//
+// value is converted to the type of the location being set if it cannot be
+// assigned directly, where this can be done without losing information, such
+// as between numeric types. Set returns an error matching [ErrTypeMismatch]
+// if value cannot be converted.
+func (p Pointer) Set(doc any, value any) error {
+	if s, ok := doc.(Setter); ok {
+		return s.SetPointer(p, value)
+	}
+
+	return setRoot(doc, p.tokens, value, setOptions{})
+}
+
+// CreateOption configures how missing values are created by
+// [Pointer.SetCreate].
+type CreateOption func(*setOptions)
+
+type setOptions struct {
+	create bool
+	arrays bool
+
+	// insert causes the last reference token to insert into an array at an
+	// existing index, rather than replacing the element.
+	insert bool
+
+	// undo records how to reverse each value created by SetCreate, so that
+	// they can be removed again if the value cannot be set.
+	undo *[]func()
+}
+
+// allocate sets the nil value v to x, recording how to undo it.
+func (o setOptions) allocate(v, x reflect.Value) {
+	v.Set(x)
+	if o.undo != nil {
+		*o.undo = append(*o.undo, v.SetZero)
+	}
+}
+
+// CreateArrays returns a [CreateOption] that causes missing values followed
+// by the reference token "0" or "-" to be created as arrays instead of
+// objects.
+func CreateArrays() CreateOption {
+	return func(o *setOptions) {
+		o.arrays = true
+	}
+}
+
+// SetCreate is like [Pointer.Set] but creates any missing values that p
+// passes through, rather than returning an error. Missing object members and
+// nil interface values are created as objects of type map[string]any, or
+// with the [CreateArrays] option, as arrays of type []any if the next
+// reference token is "0" or "-". Nil pointers and maps are allocated, and a
+// slice is extended when the reference token is "-" or its length. The last
+// reference token of p is handled as with the "add" operation of RFC 6902: an
+// existing member is replaced, and value is inserted into an array before the
+// element at an existing index, shifting the later elements. Fixed length Go
+// arrays cannot grow, so SetCreate returns an error matching
+// [ErrUnsupportedValue] for an existing index of an array. If SetCreate
+// returns an error, the values it created are removed again and doc is left
+// unchanged.
+func (p Pointer) SetCreate(doc any, value any, opts ...CreateOption) error {
+	o := setOptions{
+		create: true,
+		insert: true,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return setCreate(doc, p.tokens, value, o)
+}
+
+// setCreate sets the value referred to by tokens within doc, creating missing
+// values as described by opts and removing them again if it fails.
+func setCreate(doc any, tokens []token, value any, opts setOptions) error {
+	var undo []func()
+	opts.undo = &undo
+	err := setRoot(doc, tokens, value, opts)
+	if err != nil {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}
+
+	return err
+}
+
+func setRoot(doc any, tokens []token, value any, opts setOptions) error {
+	v := reflect.ValueOf(doc)
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return &unsupportedValueError{v.Type()}
+		}
+
+		return setReflect(v.Elem(), tokens, value, opts)
+	case reflect.Map, reflect.Slice:
+		if len(tokens) == 0 {
+			return &unsupportedValueError{v.Type()}
+		}
+
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		if err := setReflect(c, tokens, value, opts); err != nil {
+			return err
+		}
+
+		if c.Kind() == reflect.Slice && c.Len() != v.Len() {
+			// The slice has been appended to, which cannot be seen by
+			// the caller.
+			return &unsupportedValueError{v.Type()}
+		}
+
+		return nil
+	default:
+		return &unsupportedValueError{reflect.TypeOf(doc)}
+	}
+}
+
+// setReflect sets the value referred to by tokens within the settable value v.
+func setReflect(v reflect.Value, tokens []token, value any, opts setOptions) error {
+	if len(tokens) == 0 {
+		return assign(v, value)
+	}
+
+	tok := tokens[0]
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if !opts.create || !v.CanSet() {
+				return &valueNotFoundError{tok.field}
+			}
+
+			opts.allocate(v, reflect.New(v.Type().Elem()))
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Type() == rawMessageType {
+		return setRawMessage(v, tokens, value, opts)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			if !opts.create {
+				return &valueNotFoundError{tok.field}
+			}
+
+			var c any = map[string]any{}
+			if opts.arrays && (tok.field == "-" || tok.index == 0) {
+				c = []any{}
+			}
+
+			cv := reflect.ValueOf(c)
+			if !cv.Type().AssignableTo(v.Type()) {
+				return &valueNotFoundError{tok.field}
+			}
+
+			opts.allocate(v, cv)
+		}
+
+		c := reflect.New(v.Elem().Type()).Elem()
+		c.Set(v.Elem())
+		if err := setReflect(c, tokens, value, opts); err != nil {
+			return err
+		}
+
+		v.Set(c)
+		return nil
+	case reflect.Struct:
+		index, ok := getStructFields(v.Type())[tok.field]
+		if !ok {
+			return &valueNotFoundError{tok.field}
+		}
+
+		field := v
+		for i, x := range index {
+			if i > 0 && field.Kind() == reflect.Pointer {
+				if field.IsNil() {
+					if !opts.create || !field.CanSet() {
+						return &valueNotFoundError{tok.field}
+					}
+
+					opts.allocate(field, reflect.New(field.Type().Elem()))
+				}
+
+				field = field.Elem()
+			}
+
+			field = field.Field(x)
+		}
+
+		if !field.CanSet() {
+			return &valueNotFoundError{tok.field}
+		}
+
+		return setReflect(field, tokens[1:], value, opts)
+	case reflect.Map:
+		if v.IsNil() {
+			if !opts.create {
+				return &valueNotFoundError{tok.field}
+			}
+
+			opts.allocate(v, reflect.MakeMap(v.Type()))
+		}
+
+		key, ok := mapKey(v.Type().Key(), tok.field)
+		if !ok {
+			return &valueNotFoundError{tok.field}
+		}
+
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		} else if len(tokens) > 1 && !opts.create {
+			return &valueNotFoundError{tok.field}
+		}
+
+		if err := setReflect(elem, tokens[1:], value, opts); err != nil {
+			return err
+		}
+
+		v.SetMapIndex(key, elem)
+		return nil
+	case reflect.Slice:
+		if (len(tokens) == 1 || opts.create) && (tok.field == "-" || tok.index == v.Len()) {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setReflect(elem, tokens[1:], value, opts); err != nil {
+				return err
+			}
+
+			v.Set(reflect.Append(v, elem))
+			return nil
+		}
+
+		fallthrough
+	case reflect.Array:
+		if tok.index == -1 {
+			if tok.field == "-" {
+				return &arrayIndexOutOfBoundsError{v.Len()}
+			}
+
+			return &invalidArrayIndexError{tok.field}
+		}
+
+		if tok.index >= v.Len() {
+			return &arrayIndexOutOfBoundsError{tok.index}
+		}
+
+		if opts.insert && len(tokens) == 1 {
+			return insertReflect(v, tok.index, value)
+		}
+
+		return setReflect(v.Index(tok.index), tokens[1:], value, opts)
+	default:
+		return &valueNotFoundError{tok.field}
+	}
+}
+
+// insertReflect inserts value into the settable slice v before the element
+// at index, as the "add" operation of RFC 6902 does.
+func insertReflect(v reflect.Value, index int, value any) error {
+	if v.Kind() != reflect.Slice {
+		return &unsupportedValueError{v.Type()}
+	}
+
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if err := assign(elem, value); err != nil {
+		return err
+	}
+
+	c := reflect.MakeSlice(v.Type(), 0, v.Len()+1)
+	c = reflect.AppendSlice(c, v.Slice(0, index))
+	c = reflect.Append(c, elem)
+	c = reflect.AppendSlice(c, v.Slice(index, v.Len()))
+	v.Set(c)
+	return nil
+}
+
+func setRawMessage(v reflect.Value, tokens []token, value any, opts setOptions) error {
+	var doc any
+	if len(bytes.TrimSpace(v.Bytes())) == 0 {
+		if !opts.create {
+			return &valueNotFoundError{tokens[0].field}
+		}
+	} else {
+		if !json.Valid(v.Bytes()) {
+			return json.Unmarshal(v.Bytes(), new(json.RawMessage))
+		}
+
+		var err error
+		if doc, err = decodeGeneric(v.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := setReflect(reflect.ValueOf(&doc).Elem(), tokens, value, opts); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	v.SetBytes(data)
+	return nil
+}
+
+// encodedValue is a JSON encoded value that assign decodes into the type of
+// the location being set.
+type encodedValue []byte
+
+// stringValue returns the value that the string s is set as in a location
+// of type t. If t is nil or a string type, s is used as it is, and otherwise s
+// is decoded as JSON, or as a JSON string if it is not valid JSON.
+func stringValue(t reflect.Type, s string) any {
+	if t == nil || t.Kind() == reflect.String {
+		return s
+	}
+
+	if json.Valid([]byte(s)) {
+		return encodedValue(s)
+	}
+
+	data, _ := json.Marshal(s)
+	return encodedValue(data)
+}
+
+// assign sets the settable value v to value, converting value to the type of
+// v where this can be done without losing information.
+func assign(v reflect.Value, value any) error {
+	if value == nil {
+		v.SetZero()
+		return nil
+	}
+
+	if data, ok := value.(encodedValue); ok {
+		c := reflect.New(v.Type())
+		if err := json.Unmarshal(data, c.Interface()); err != nil {
+			var terr *json.UnmarshalTypeError
+			if errors.As(err, &terr) {
+				return &typeMismatchError{terr.Type, jsonValueType(terr.Value)}
+			}
+
+			return err
+		}
+
+		v.Set(c.Elem())
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(v.Type()) {
+		v.Set(rv)
+		return nil
+	}
+
+	if n, ok := value.(json.Number); ok {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			f, err := n.Float64()
+			if err != nil {
+				return &typeMismatchError{v.Type(), rv.Type()}
+			}
+
+			if i, err := n.Int64(); err == nil {
+				rv = reflect.ValueOf(i)
+			} else {
+				rv = reflect.ValueOf(f)
+			}
+		}
+	}
+
+	if convertNumber(v, rv) {
+		return nil
+	}
+
+	if rv.Kind() == reflect.String && v.Kind() == reflect.String {
+		v.SetString(rv.String())
+		return nil
+	}
+
+	if rv.Kind() == reflect.Bool && v.Kind() == reflect.Bool {
+		v.SetBool(rv.Bool())
+		return nil
+	}
+
+	return &typeMismatchError{v.Type(), reflect.TypeOf(value)}
+}
+
+// jsonValueType returns the type that a JSON value described by kind, as in
+// the Value field of [json.UnmarshalTypeError], is decoded to in an any value.
+func jsonValueType(kind string) reflect.Type {
+	kind, _, _ = strings.Cut(kind, " ")
+	switch kind {
+	case "array":
+		return reflect.TypeFor[[]any]()
+	case "bool":
+		return reflect.TypeFor[bool]()
+	case "number":
+		return reflect.TypeFor[float64]()
+	case "object":
+		return reflect.TypeFor[map[string]any]()
+	default:
+		return reflect.TypeFor[string]()
+	}
+}
+
+// convertNumber sets v to the number rv if both are numbers and rv can be
+// represented exactly by the type of v.
+func convertNumber(v, rv reflect.Value) bool {
+	var f float64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := rv.Int()
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(n) {
+				return false
+			}
+
+			v.SetInt(n)
+			return true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if n < 0 || v.OverflowUint(uint64(n)) {
+				return false
+			}
+
+			v.SetUint(uint64(n))
+			return true
+		}
+
+		f = float64(n)
+		if int64(f) != n {
+			return false
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := rv.Uint()
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if n > math.MaxInt64 || v.OverflowInt(int64(n)) {
+				return false
+			}
+
+			v.SetInt(int64(n))
+			return true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if v.OverflowUint(n) {
+				return false
+			}
+
+			v.SetUint(n)
+			return true
+		}
+
+		f = float64(n)
+		if uint64(f) != n {
+			return false
+		}
+	case reflect.Float32, reflect.Float64:
+		f = rv.Float()
+	default:
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if v.OverflowFloat(f) {
+			return false
+		}
+
+		v.SetFloat(f)
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || v.OverflowInt(int64(f)) {
+			return false
+		}
+
+		v.SetInt(int64(f))
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || v.OverflowUint(uint64(f)) {
+			return false
+		}
+
+		v.SetUint(uint64(f))
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,388 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPointerSet(t *testing.T) {
+	t.Parallel()
+
+	var doc any = map[string]any{
+		"a": []any{
+			map[string]any{},
+		},
+		"b": map[string]any{},
+	}
+
+	type test struct {
+		ptr   string
+		value any
+	}
+
+	tests := []test{
+		{"/a/0/c", "d"},
+		{"/a/-", 1.0},
+		{"/a/2", 2.0},
+		{"/a/0/c", "e"},
+		{"/b/f~1g", nil},
+	}
+
+	for _, test := range tests {
+		if err := MustParse(test.ptr).Set(doc, test.value); err != nil {
+			t.Fatalf("Pointer(%s).Set() = %v, want <nil>", test.ptr, err)
+		}
+	}
+
+	want := map[string]any{
+		"a": []any{
+			map[string]any{
+				"c": "e",
+			},
+			1.0,
+			2.0,
+		},
+		"b": map[string]any{
+			"f/g": nil,
+		},
+	}
+
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Pointer.Set() = %v, want %v", doc, want)
+	}
+
+	type Options struct {
+		Timeout int `json:"timeout"`
+	}
+
+	type Host struct {
+		*Options
+		Port uint16 `json:"port"`
+	}
+
+	type Config struct {
+		Hosts []Host            `json:"hosts"`
+		ByID  map[int]*Host     `json:"by_id"`
+		Ratio float32           `json:"ratio"`
+		Extra any               `json:"extra"`
+		Raw   json.RawMessage   `json:"raw"`
+		Blank json.RawMessage   `json:"blank"`
+		Tags  map[string]string `json:"tags"`
+	}
+
+	cfg := &Config{
+		Hosts: []Host{
+			{Options: &Options{}},
+		},
+		ByID: map[int]*Host{
+			1: {},
+		},
+		Extra: map[string]any{},
+		Raw:   json.RawMessage(`{"a": [1]}`),
+		Blank: json.RawMessage(" "),
+	}
+
+	tests = []test{
+		{"/hosts/0/port", 8080.0},
+		{"/hosts/0/timeout", json.Number("30")},
+		{"/hosts/-", Host{Port: 1}},
+		{"/by_id/1/port", 2},
+		{"/ratio", 0.5},
+		{"/extra/a", "b"},
+		{"/raw/a/-", 2},
+	}
+
+	for _, test := range tests {
+		if err := MustParse(test.ptr).Set(cfg, test.value); err != nil {
+			t.Fatalf("Pointer(%s).Set() = %v, want <nil>", test.ptr, err)
+		}
+	}
+
+	if cfg.Hosts[0].Port != 8080 || cfg.Hosts[0].Timeout != 30 || len(cfg.Hosts) != 2 || cfg.Hosts[1].Port != 1 {
+		t.Errorf("Pointer.Set() = %+v, want hosts set", cfg.Hosts)
+	}
+
+	if cfg.ByID[1].Port != 2 || cfg.Ratio != 0.5 || cfg.Extra.(map[string]any)["a"] != "b" {
+		t.Errorf("Pointer.Set() = %+v, want fields set", cfg)
+	}
+
+	if string(cfg.Raw) != `{"a":[1,2]}` {
+		t.Errorf("Pointer.Set() = %s, want %s", cfg.Raw, `{"a":[1,2]}`)
+	}
+
+	type errTest struct {
+		ptr   string
+		value any
+		err   error
+	}
+
+	errTests := []errTest{
+		{"/hosts/0/port", 1.5, ErrTypeMismatch},
+		{"/hosts/0/port", 70000, ErrTypeMismatch},
+		{"/hosts/0/port", "1", ErrTypeMismatch},
+		{"/hosts/5/port", 1, ErrArrayIndexOutOfBounds},
+		{"/hosts/1/timeout", 1, ErrValueNotFound},
+		{"/tags/a", "b", ErrValueNotFound},
+		{"/missing", 1, ErrValueNotFound},
+		{"/blank/a", 1, ErrValueNotFound},
+	}
+
+	for _, test := range errTests {
+		err := MustParse(test.ptr).Set(cfg, test.value)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Pointer(%s).Set(%v) = %v, want %v", test.ptr, test.value, err, test.err)
+		}
+	}
+
+	err := MustParse("/-").Set([]any{}, 1)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Pointer(/-).Set() = %v, want %v", err, ErrUnsupportedValue)
+	}
+
+	cfg.Raw = json.RawMessage(`{"id": 12345678901234567891, "x": 1}`)
+	if err := MustParse("/raw/x").Set(cfg, 2); err != nil {
+		t.Fatalf("Pointer(/raw/x).Set() = %v, want <nil>", err)
+	}
+
+	if want := `{"id":12345678901234567891,"x":2}`; string(cfg.Raw) != want {
+		t.Errorf("Pointer(/raw/x).Set() = %s, want %s", cfg.Raw, want)
+	}
+}
+
+func TestPointerSetCreate(t *testing.T) {
+	t.Parallel()
+
+	var doc any
+
+	type test struct {
+		ptr   string
+		value any
+	}
+
+	tests := []test{
+		{"/a/b/c", 1.0},
+		{"/a/b/d", 2.0},
+		{"/e/-/f", "g"},
+		{"/e/0/h", "i"},
+		{"/e/-", "j"},
+	}
+
+	for _, test := range tests {
+		if err := MustParse(test.ptr).SetCreate(&doc, test.value, CreateArrays()); err != nil {
+			t.Fatalf("Pointer(%s).SetCreate() = %v, want <nil>", test.ptr, err)
+		}
+	}
+
+	want := map[string]any{
+		"a": map[string]any{
+			"b": map[string]any{
+				"c": 1.0,
+				"d": 2.0,
+			},
+		},
+		"e": []any{
+			map[string]any{
+				"f": "g",
+				"h": "i",
+			},
+			"j",
+		},
+	}
+
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", doc, want)
+	}
+
+	doc = nil
+	if err := MustParse("/a/0").SetCreate(&doc, 1.0); err != nil {
+		t.Fatalf("Pointer.SetCreate() = %v, want <nil>", err)
+	}
+
+	want = map[string]any{
+		"a": map[string]any{
+			"0": 1.0,
+		},
+	}
+
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", doc, want)
+	}
+
+	type Options struct {
+		Timeout int `json:"timeout"`
+	}
+
+	type Host struct {
+		*Options
+		Port int `json:"port"`
+	}
+
+	type Config struct {
+		DB     *Host            `json:"db"`
+		Hosts  []*Host          `json:"hosts"`
+		Labels map[string]*Host `json:"labels"`
+		Raw    json.RawMessage  `json:"raw"`
+	}
+
+	var cfg Config
+
+	tests = []test{
+		{"/db/timeout", 1},
+		{"/hosts/-/port", 2},
+		{"/hosts/1/timeout", 3},
+		{"/labels/a/port", 4},
+		{"/raw/b/c", 5},
+	}
+
+	for _, test := range tests {
+		if err := MustParse(test.ptr).SetCreate(&cfg, test.value); err != nil {
+			t.Fatalf("Pointer(%s).SetCreate() = %v, want <nil>", test.ptr, err)
+		}
+	}
+
+	if cfg.DB == nil || cfg.DB.Options == nil || cfg.DB.Timeout != 1 {
+		t.Errorf("Pointer.SetCreate() set %+v, want timeout 1", cfg.DB)
+	}
+
+	if len(cfg.Hosts) != 2 || cfg.Hosts[0].Port != 2 || cfg.Hosts[1].Timeout != 3 {
+		t.Errorf("Pointer.SetCreate() set %+v, want 2 hosts", cfg.Hosts)
+	}
+
+	if cfg.Labels["a"] == nil || cfg.Labels["a"].Port != 4 {
+		t.Errorf("Pointer.SetCreate() set %+v, want port 4", cfg.Labels)
+	}
+
+	if string(cfg.Raw) != `{"b":{"c":5}}` {
+		t.Errorf("Pointer.SetCreate() set %s, want {\"b\":{\"c\":5}}", cfg.Raw)
+	}
+
+	err := MustParse("/hosts/5/port").SetCreate(&cfg, 6)
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	err = MustParse("/db/port/a").SetCreate(&cfg, 7)
+	if !errors.Is(err, ErrValueNotFound) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", err, ErrValueNotFound)
+	}
+
+	var list any = map[string]any{
+		"a": []any{"x", "y"},
+	}
+
+	if err := MustParse("/a/1").SetCreate(&list, "v"); err != nil {
+		t.Fatalf("Pointer.SetCreate() = %v, want <nil>", err)
+	}
+
+	if want := []any{"x", "v", "y"}; !reflect.DeepEqual(list.(map[string]any)["a"], want) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", list, want)
+	}
+
+	names := struct {
+		Names []string  `json:"names"`
+		Pair  [2]string `json:"pair"`
+	}{
+		Names: []string{"x", "y"},
+	}
+
+	if err := MustParse("/names/0").SetCreate(&names, "v"); err != nil {
+		t.Fatalf("Pointer.SetCreate() = %v, want <nil>", err)
+	}
+
+	if want := []string{"v", "x", "y"}; !reflect.DeepEqual(names.Names, want) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", names.Names, want)
+	}
+
+	err = MustParse("/pair/0").SetCreate(&names, "v")
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Pointer.SetCreate() = %v, want %v", err, ErrUnsupportedValue)
+	}
+
+	type Partial struct {
+		P      *Host            `json:"p"`
+		Labels map[string]*Host `json:"labels"`
+	}
+
+	var partial Partial
+	tests = []test{
+		{"/p/timeout", "str"},
+		{"/labels/a/timeout", "str"},
+	}
+
+	for _, test := range tests {
+		err := MustParse(test.ptr).SetCreate(&partial, test.value, CreateArrays())
+		if err == nil {
+			t.Fatalf("Pointer(%s).SetCreate() = <nil>, want error", test.ptr)
+		}
+
+		if !reflect.DeepEqual(partial, Partial{}) {
+			t.Errorf("Pointer(%s).SetCreate() left %+v, want %+v", test.ptr, partial, Partial{})
+		}
+	}
+}
+
+type getterSetter struct {
+	values map[string]any
+}
+
+func (g *getterSetter) GetPointer(p Pointer) (any, error) {
+	v, ok := g.values[p.String()]
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+
+	return v, nil
+}
+
+func (g *getterSetter) SetPointer(p Pointer, value any) error {
+	g.values[p.String()] = value
+	return nil
+}
+
+// rawGetter resolves pointers against its own value with reflection, as
+// generated code does for values it has no typed path for.
+type rawGetter struct {
+	Body json.RawMessage `json:"body"`
+}
+
+func (g *rawGetter) GetPointer(p Pointer) (any, error) {
+	return p.Get(*g)
+}
+
+func TestGetterSetter(t *testing.T) {
+	t.Parallel()
+
+	g := &getterSetter{
+		values: make(map[string]any),
+	}
+
+	if err := MustParse("/a~1b/c").Set(g, "d"); err != nil {
+		t.Fatalf("Pointer.Set() = %v, want <nil>", err)
+	}
+
+	result, err := MustParse("/a~1b/c").Get(g)
+	if result != "d" || err != nil {
+		t.Errorf("Pointer.Get() = (%v, %v), want (d, <nil>)", result, err)
+	}
+
+	result, err = Get("/a~1b/c", g)
+	if result != "d" || err != nil {
+		t.Errorf("Get() = (%v, %v), want (d, <nil>)", result, err)
+	}
+
+	raw := &rawGetter{
+		Body: json.RawMessage(`{"a": {"b": 1}}`),
+	}
+
+	result, err = MustParse("/body/a").Get(raw, KeepRawMessages())
+	if r, ok := result.(json.RawMessage); !ok || string(r) != `{"b": 1}` || err != nil {
+		t.Errorf("Pointer.Get() = (%v, %v), want ({\"b\": 1}, <nil>)", result, err)
+	}
+
+	result, err = Get("/body/a", raw, KeepRawMessages())
+	if r, ok := result.(json.RawMessage); !ok || string(r) != `{"b": 1}` || err != nil {
+		t.Errorf("Get() = (%v, %v), want ({\"b\": 1}, <nil>)", result, err)
+	}
+}
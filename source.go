@@ -0,0 +1,304 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Source holds encoded JSON along with the location of every value within it,
+// allowing JSON pointers to be mapped to positions in the encoded JSON and
+// back.
+type Source struct {
+	data  []byte
+	root  sourceNode
+	lines []int
+}
+
+type sourceNode struct {
+	tok      token
+	keyStart int
+	keyEnd   int
+	start    int
+	end      int
+	children []sourceNode
+}
+
+// Position describes a position in encoded JSON. Offset is a byte offset
+// from the start of the encoded JSON, starting at 0. Line and Column start at
+// 1, and Column is counted in bytes.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Span describes a range of encoded JSON, from Start up to but not including
+// End.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// Location describes where a value is found in encoded JSON. Key is the span
+// of the member name, including its quotes, if the value is a member of an
+// object, and is the zero Span otherwise.
+type Location struct {
+	Key   Span
+	Value Span
+}
+
+// ParseSource scans the encoded JSON data and records the location of every
+// value within it. ParseSource returns a [*json.SyntaxError] if data is not
+// valid JSON.
+func ParseSource(data []byte) (*Source, error) {
+	if !json.Valid(data) {
+		var v any
+		return nil, json.Unmarshal(data, &v)
+	}
+
+	s := &Source{
+		data:  data,
+		lines: []int{0},
+	}
+
+	for i, c := range data {
+		if c == '\n' {
+			s.lines = append(s.lines, i+1)
+		}
+	}
+
+	sc := scanner{
+		data: data,
+	}
+
+	sc.skipSpace()
+	s.root = sourceNode{
+		keyStart: -1,
+		keyEnd:   -1,
+	}
+
+	sc.value(&s.root)
+	return s, nil
+}
+
+// Locate returns the location of the value referred to by the JSON pointer p.
+func (s *Source) Locate(p Pointer) (Location, error) {
+	n, err := s.find(p)
+	if err != nil {
+		return Location{}, err
+	}
+
+	var loc Location
+	if n.keyStart != -1 {
+		loc.Key = s.span(n.keyStart, n.keyEnd)
+	}
+
+	loc.Value = s.span(n.start, n.end)
+	return loc, nil
+}
+
+// PointerAt returns the JSON pointer of the innermost value whose location,
+// including its member name, contains offset. PointerAt returns false if
+// offset is outside of the top level value.
+func (s *Source) PointerAt(offset int) (Pointer, bool) {
+	n := &s.root
+	if offset < n.start || offset >= n.end {
+		return Pointer{}, false
+	}
+
+	var tokens []token
+	for {
+		i := sort.Search(len(n.children), func(i int) bool {
+			return n.children[i].end > offset
+		})
+
+		if i == len(n.children) {
+			break
+		}
+
+		c := &n.children[i]
+		start := c.start
+		if c.keyStart != -1 {
+			start = c.keyStart
+		}
+
+		if offset < start {
+			break
+		}
+
+		tokens = append(tokens, c.tok)
+		n = c
+	}
+
+	return Pointer{
+		tokens: tokens,
+	}, true
+}
+
+// Position returns the Position of the byte offset in the encoded JSON.
+// Position returns false if offset is negative or past the end of the encoded
+// JSON. The offset of the end of the encoded JSON is itself allowed, as it is
+// the end of a [Span].
+func (s *Source) Position(offset int) (Position, bool) {
+	if offset < 0 || offset > len(s.data) {
+		return Position{}, false
+	}
+
+	return s.position(offset), true
+}
+
+func (s *Source) position(offset int) Position {
+	line := sort.SearchInts(s.lines, offset+1) - 1
+	return Position{
+		Offset: offset,
+		Line:   line + 1,
+		Column: offset - s.lines[line] + 1,
+	}
+}
+
+func (s *Source) find(p Pointer) (*sourceNode, error) {
+	n := &s.root
+	for _, tok := range p.tokens {
+		switch s.data[n.start] {
+		case '{':
+			// Later members take precedence over earlier members with the
+			// same name, as they do when decoding.
+			i := len(n.children) - 1
+			for i >= 0 && n.children[i].tok.field != tok.field {
+				i--
+			}
+
+			if i == -1 {
+				return nil, &valueNotFoundError{tok.field}
+			}
+
+			n = &n.children[i]
+		case '[':
+			if tok.index == -1 {
+				if tok.field == "-" {
+					return nil, &arrayIndexOutOfBoundsError{len(n.children)}
+				}
+
+				return nil, &invalidArrayIndexError{tok.field}
+			}
+
+			if tok.index >= len(n.children) {
+				return nil, &arrayIndexOutOfBoundsError{tok.index}
+			}
+
+			n = &n.children[tok.index]
+		default:
+			return nil, &valueNotFoundError{tok.field}
+		}
+	}
+
+	return n, nil
+}
+
+func (s *Source) span(start, end int) Span {
+	return Span{
+		Start: s.position(start),
+		End:   s.position(end),
+	}
+}
+
+// scanner records the locations of values in encoded JSON that is known to be
+// valid.
+type scanner struct {
+	data []byte
+	pos  int
+}
+
+func (sc *scanner) skipSpace() {
+	for sc.pos < len(sc.data) {
+		switch sc.data[sc.pos] {
+		case ' ', '\t', '\r', '\n':
+			sc.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (sc *scanner) value(n *sourceNode) {
+	n.start = sc.pos
+	switch sc.data[sc.pos] {
+	case '{':
+		sc.pos++
+		sc.skipSpace()
+		for sc.data[sc.pos] != '}' {
+			c := sourceNode{
+				keyStart: sc.pos,
+			}
+
+			sc.string()
+			c.keyEnd = sc.pos
+
+			var key string
+			json.Unmarshal(sc.data[c.keyStart:c.keyEnd], &key)
+			c.tok = makeToken(key)
+
+			sc.skipSpace()
+			sc.pos++
+			sc.skipSpace()
+			sc.value(&c)
+			n.children = append(n.children, c)
+
+			sc.skipSpace()
+			if sc.data[sc.pos] == ',' {
+				sc.pos++
+				sc.skipSpace()
+			}
+		}
+
+		sc.pos++
+	case '[':
+		sc.pos++
+		sc.skipSpace()
+		for sc.data[sc.pos] != ']' {
+			c := sourceNode{
+				tok:      indexToken(len(n.children)),
+				keyStart: -1,
+				keyEnd:   -1,
+			}
+
+			sc.value(&c)
+			n.children = append(n.children, c)
+
+			sc.skipSpace()
+			if sc.data[sc.pos] == ',' {
+				sc.pos++
+				sc.skipSpace()
+			}
+		}
+
+		sc.pos++
+	case '"':
+		sc.string()
+	default:
+		for sc.pos < len(sc.data) {
+			switch sc.data[sc.pos] {
+			case ',', ']', '}', ' ', '\t', '\r', '\n':
+				n.end = sc.pos
+				return
+			}
+
+			sc.pos++
+		}
+	}
+
+	n.end = sc.pos
+}
+
+func (sc *scanner) string() {
+	sc.pos++
+	for sc.data[sc.pos] != '"' {
+		if sc.data[sc.pos] == '\\' {
+			sc.pos++
+		}
+
+		sc.pos++
+	}
+
+	sc.pos++
+}
@@ -0,0 +1,95 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+  "a": [1, {"b~c": "d"}],
+  "e/f": null
+}`)
+
+	s, err := ParseSource(data)
+	if err != nil {
+		t.Fatalf("ParseSource() = %v, want <nil>", err)
+	}
+
+	type test struct {
+		ptr   string
+		key   string
+		value string
+		line  int
+		col   int
+	}
+
+	tests := []test{
+		{"", "", string(data), 1, 1},
+		{"/a", `"a"`, `[1, {"b~c": "d"}]`, 2, 8},
+		{"/a/0", "", `1`, 2, 9},
+		{"/a/1/b~0c", `"b~c"`, `"d"`, 2, 20},
+		{"/e~1f", `"e/f"`, `null`, 3, 10},
+	}
+
+	for _, test := range tests {
+		loc, err := s.Locate(MustParse(test.ptr))
+		if err != nil {
+			t.Fatalf("Source.Locate(%s) = %v, want <nil>", test.ptr, err)
+		}
+
+		key := string(data[loc.Key.Start.Offset:loc.Key.End.Offset])
+		value := string(data[loc.Value.Start.Offset:loc.Value.End.Offset])
+		if key != test.key || value != test.value {
+			t.Errorf("Source.Locate(%s) = (%s, %s), want (%s, %s)", test.ptr, key, value, test.key, test.value)
+		}
+
+		if loc.Value.Start.Line != test.line || loc.Value.Start.Column != test.col {
+			t.Errorf("Source.Locate(%s).Value.Start = %d:%d, want %d:%d", test.ptr, loc.Value.Start.Line, loc.Value.Start.Column, test.line, test.col)
+		}
+
+		p, ok := s.PointerAt(loc.Value.Start.Offset)
+		if !ok || p.String() != test.ptr {
+			t.Errorf("Source.PointerAt(%d) = (%s, %t), want (%s, true)", loc.Value.Start.Offset, p, ok, test.ptr)
+		}
+	}
+
+	p, ok := s.PointerAt(4)
+	if !ok || p.String() != "/a" {
+		t.Errorf("Source.PointerAt(4) = (%s, %t), want (/a, true)", p, ok)
+	}
+
+	p, ok = s.PointerAt(11)
+	if !ok || p.String() != "/a" {
+		t.Errorf("Source.PointerAt(11) = (%s, %t), want (/a, true)", p, ok)
+	}
+
+	if _, ok := s.PointerAt(len(data)); ok {
+		t.Errorf("Source.PointerAt(%d) = true, want false", len(data))
+	}
+
+	pos, ok := s.Position(len(data))
+	if !ok || pos.Offset != len(data) {
+		t.Errorf("Source.Position(%d) = (%+v, %t), want (%d, true)", len(data), pos, ok, len(data))
+	}
+
+	for _, offset := range []int{-1, len(data) + 1} {
+		if _, ok := s.Position(offset); ok {
+			t.Errorf("Source.Position(%d) = true, want false", offset)
+		}
+	}
+
+	_, err = s.Locate(MustParse("/a/2"))
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("Source.Locate(/a/2) = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	var serr *json.SyntaxError
+	_, err = ParseSource([]byte(`{"a": }`))
+	if !errors.As(err, &serr) {
+		t.Errorf("ParseSource() = %v, want *json.SyntaxError", err)
+	}
+}
@@ -14,7 +14,13 @@ func structField(field string, value *reflect.Value) bool {
 		return false
 	}
 
-	*value = value.FieldByIndex(i)
+	// A field promoted through a nil embedded pointer does not exist.
+	f, err := value.FieldByIndexErr(i)
+	if err != nil {
+		return false
+	}
+
+	*value = f
 	return true
 }
 
@@ -10,34 +10,38 @@ type token struct {
 	index int
 }
 
-func parseToken(tok string) (token, error) {
-	if len(tok) == 0 {
+func makeToken(field string) token {
+	if field == "0" {
 		return token{
-			index: -1,
-		}, nil
+			field: "0",
+		}
 	}
 
-	i := strings.IndexByte(tok, '~')
-	if i == -1 {
-		if tok == "0" {
-			return token{
-				field: "0",
-			}, nil
+	if len(field) > 0 && field[0] >= '1' && field[0] <= '9' {
+		return token{
+			field: field,
+			index: atoi(field),
 		}
+	}
 
-		if r := tok[0]; r >= '1' && r <= '9' {
-			return token{
-				field: tok,
-				index: atoi(tok),
-			}, nil
-		}
+	return token{
+		field: field,
+		index: -1,
+	}
+}
 
+func parseToken(tok string) (token, error) {
+	if len(tok) == 0 {
 		return token{
-			field: tok,
 			index: -1,
 		}, nil
 	}
 
+	i := strings.IndexByte(tok, '~')
+	if i == -1 {
+		return makeToken(tok), nil
+	}
+
 	var b strings.Builder
 	b.Grow(len(tok))
 	b.WriteString(tok[:i])
@@ -48,7 +52,7 @@ func parseToken(tok string) (token, error) {
 			return token{}, &invalidTokenError{tok}
 		}
 
-		switch tok[1] {
+		switch remaining[1] {
 		case '0':
 			b.WriteByte('~')
 		case '1':
@@ -113,7 +117,7 @@ func parseTokenBytes(tok []byte) (token, error) {
 			return token{}, &invalidTokenError{string(tok)}
 		}
 
-		switch tok[1] {
+		switch remaining[1] {
 		case '0':
 			b.WriteByte('~')
 		case '1':
@@ -138,6 +142,24 @@ func parseTokenBytes(tok []byte) (token, error) {
 	}
 }
 
+func appendToken(buf []byte, field string) []byte {
+	for {
+		i := strings.IndexAny(field, "~/")
+		if i == -1 {
+			return append(buf, field...)
+		}
+
+		buf = append(buf, field[:i]...)
+		if field[i] == '~' {
+			buf = append(buf, '~', '0')
+		} else {
+			buf = append(buf, '~', '1')
+		}
+
+		field = field[i+1:]
+	}
+}
+
 func atoi(s string) int {
 	var n int
 	for _, r := range s {
@@ -25,6 +25,8 @@ func TestParseToken(t *testing.T) {
 		{"~1", "/", -1},
 		{"~01", "~1", -1},
 		{"~10", "/0", -1},
+		{"a~1b~0c", "a/b~c", -1},
+		{"~0~1", "~/", -1},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,134 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// PathTracker tracks the JSON pointer of the tokens read from a
+// [json.Decoder]. Tokens may either be read through the PathTracker using
+// [PathTracker.Token] and [PathTracker.Decode], or read elsewhere and passed
+// to [PathTracker.Track]. The zero value is a PathTracker ready to use with
+// [PathTracker.Track].
+type PathTracker struct {
+	dec    *json.Decoder
+	tokens []token
+	frames []pathFrame
+}
+
+type pathFrame struct {
+	array bool
+	index int
+
+	// awaitingKey is set when the next token read within an object is a
+	// member name.
+	awaitingKey bool
+}
+
+// NewPathTracker returns a PathTracker that reads tokens from dec.
+func NewPathTracker(dec *json.Decoder) *PathTracker {
+	return &PathTracker{
+		dec: dec,
+	}
+}
+
+// Decode is like [json.Decoder.Decode], and records the JSON pointer of the
+// decoded value.
+func (t *PathTracker) Decode(v any) error {
+	t.value()
+	if err := t.dec.Decode(v); err != nil {
+		return err
+	}
+
+	t.valueDone()
+	return nil
+}
+
+// More is like [json.Decoder.More].
+func (t *PathTracker) More() bool {
+	return t.dec.More()
+}
+
+// Pointer returns the JSON pointer of the most recently read token. For a
+// member name, this is the pointer of the member's value. For a delimiter,
+// this is the pointer of the object or array that it begins or ends.
+func (t *PathTracker) Pointer() Pointer {
+	return Pointer{
+		tokens: slices.Clone(t.tokens),
+	}
+}
+
+// Token is like [json.Decoder.Token], and records the JSON pointer of the
+// token that is read.
+func (t *PathTracker) Token() (json.Token, error) {
+	tok, err := t.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	t.Track(tok)
+	return tok, nil
+}
+
+// Track records the JSON pointer of tok, which must be the token that follows
+// the tokens previously passed to Track.
+func (t *PathTracker) Track(tok json.Token) {
+	n := len(t.frames)
+	if d, ok := tok.(json.Delim); ok {
+		switch d {
+		case '{', '[':
+			t.value()
+			t.frames = append(t.frames, pathFrame{
+				array:       d == '[',
+				index:       -1,
+				awaitingKey: d == '{',
+			})
+		case '}', ']':
+			if n == 0 {
+				return
+			}
+
+			t.frames = t.frames[:n-1]
+			t.tokens = t.tokens[:n-1]
+			t.valueDone()
+		}
+
+		return
+	}
+
+	if n > 0 && t.frames[n-1].awaitingKey {
+		key, _ := tok.(string)
+		t.tokens = append(t.tokens[:n-1], makeToken(key))
+		t.frames[n-1].awaitingKey = false
+		return
+	}
+
+	t.value()
+	t.valueDone()
+}
+
+// value records the start of a value within the current object or array.
+func (t *PathTracker) value() {
+	n := len(t.frames)
+	if n == 0 {
+		t.tokens = t.tokens[:0]
+		return
+	}
+
+	f := &t.frames[n-1]
+	if f.array {
+		f.index++
+		t.tokens = append(t.tokens[:n-1], indexToken(f.index))
+		return
+	}
+
+	t.tokens = t.tokens[:n]
+}
+
+// valueDone records the end of a value within the current object or array.
+func (t *PathTracker) valueDone() {
+	n := len(t.frames)
+	if n > 0 && !t.frames[n-1].array {
+		t.frames[n-1].awaitingKey = true
+	}
+}
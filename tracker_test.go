@@ -0,0 +1,78 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPathTracker(t *testing.T) {
+	t.Parallel()
+
+	dec := json.NewDecoder(strings.NewReader(`{"a": [1, [2, {"b/c": 3}], {}], "d": {"e": null}, "f": 4} 5`))
+	tracker := NewPathTracker(dec)
+
+	var ptrs []string
+	for {
+		_, err := tracker.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("PathTracker.Token() = %v, want <nil>", err)
+		}
+
+		ptrs = append(ptrs, tracker.Pointer().String())
+	}
+
+	want := []string{
+		"",            // {
+		"/a",          // "a"
+		"/a",          // [
+		"/a/0",        // 1
+		"/a/1",        // [
+		"/a/1/0",      // 2
+		"/a/1/1",      // {
+		"/a/1/1/b~1c", // "b/c"
+		"/a/1/1/b~1c", // 3
+		"/a/1/1",      // }
+		"/a/1",        // ]
+		"/a/2",        // {
+		"/a/2",        // }
+		"/a",          // ]
+		"/d",          // "d"
+		"/d",          // {
+		"/d/e",        // "e"
+		"/d/e",        // null
+		"/d",          // }
+		"/f",          // "f"
+		"/f",          // 4
+		"",            // }
+		"",            // 5
+	}
+
+	if !slices.Equal(ptrs, want) {
+		t.Errorf("PathTracker.Pointer() = %q, want %q", ptrs, want)
+	}
+
+	dec = json.NewDecoder(strings.NewReader(`[{"a": 1}, {"a": 2}]`))
+	tracker = NewPathTracker(dec)
+	if _, err := tracker.Token(); err != nil {
+		t.Fatalf("PathTracker.Token() = %v, want <nil>", err)
+	}
+
+	for tracker.More() {
+		var v struct{ A int }
+		if err := tracker.Decode(&v); err != nil {
+			t.Fatalf("PathTracker.Decode() = %v, want <nil>", err)
+		}
+
+		if want := "/" + strconv.Itoa(v.A-1); tracker.Pointer().String() != want {
+			t.Errorf("PathTracker.Pointer() = %s, want %s", tracker.Pointer(), want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package jsonpointer
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Trie is a set of JSON pointers stored by reference token, supporting
+// queries for the pointers that prefix, or are prefixed by, another pointer.
+// Reference tokens are compared in their unescaped form, so "/a~1b" and
+// "/a/b" are always distinct. The zero value is an empty Trie ready to use.
+type Trie struct {
+	root trieNode
+	len  int
+}
+
+type trieNode struct {
+	tok      token
+	children map[string]*trieNode
+	present  bool
+}
+
+// Add adds the JSON pointer p to the Trie. Add reports whether p was not
+// already present in the Trie.
+func (t *Trie) Add(p Pointer) bool {
+	n := &t.root
+	for _, tok := range p.tokens {
+		child, ok := n.children[tok.field]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[string]*trieNode)
+			}
+
+			child = &trieNode{
+				tok: tok,
+			}
+
+			n.children[tok.field] = child
+		}
+
+		n = child
+	}
+
+	if n.present {
+		return false
+	}
+
+	n.present = true
+	t.len++
+	return true
+}
+
+// All returns an iterator over the JSON pointers in the Trie. Pointers are
+// yielded before their descendants, and siblings are yielded in order of
+// their reference tokens.
+func (t *Trie) All() iter.Seq[Pointer] {
+	return t.Descendants(Pointer{})
+}
+
+// Contains reports whether the JSON pointer p is present in the Trie.
+func (t *Trie) Contains(p Pointer) bool {
+	n := t.find(p)
+	return n != nil && n.present
+}
+
+// ContainsPrefixOf reports whether the Trie contains p or any ancestor of p.
+func (t *Trie) ContainsPrefixOf(p Pointer) bool {
+	_, ok := t.LongestMatch(p)
+	return ok
+}
+
+// Descendants returns an iterator over the JSON pointers in the Trie that
+// have p as a prefix, including p itself. Pointers are yielded in the same
+// order as [Trie.All].
+func (t *Trie) Descendants(p Pointer) iter.Seq[Pointer] {
+	return func(yield func(Pointer) bool) {
+		n := t.find(p)
+		if n == nil {
+			return
+		}
+
+		n.walk(slices.Clone(p.tokens), yield)
+	}
+}
+
+// Len returns the number of JSON pointers in the Trie.
+func (t *Trie) Len() int {
+	return t.len
+}
+
+// LongestMatch returns the longest JSON pointer in the Trie that is p or an
+// ancestor of p. LongestMatch returns false if there is no such pointer.
+func (t *Trie) LongestMatch(p Pointer) (Pointer, bool) {
+	n := &t.root
+	match := -1
+	if n.present {
+		match = 0
+	}
+
+	for i, tok := range p.tokens {
+		child, ok := n.children[tok.field]
+		if !ok {
+			break
+		}
+
+		n = child
+		if n.present {
+			match = i + 1
+		}
+	}
+
+	if match == -1 {
+		return Pointer{}, false
+	}
+
+	return Pointer{tokens: p.tokens[:match:match]}, true
+}
+
+// Remove removes the JSON pointer p from the Trie. Remove reports whether p
+// was present in the Trie.
+func (t *Trie) Remove(p Pointer) bool {
+	if !t.root.remove(p.tokens) {
+		return false
+	}
+
+	t.len--
+	return true
+}
+
+func (t *Trie) find(p Pointer) *trieNode {
+	n := &t.root
+	for _, tok := range p.tokens {
+		child, ok := n.children[tok.field]
+		if !ok {
+			return nil
+		}
+
+		n = child
+	}
+
+	return n
+}
+
+func (n *trieNode) remove(tokens []token) bool {
+	if len(tokens) == 0 {
+		if !n.present {
+			return false
+		}
+
+		n.present = false
+		return true
+	}
+
+	child, ok := n.children[tokens[0].field]
+	if !ok || !child.remove(tokens[1:]) {
+		return false
+	}
+
+	if !child.present && len(child.children) == 0 {
+		delete(n.children, tokens[0].field)
+	}
+
+	return true
+}
+
+func (n *trieNode) walk(tokens []token, yield func(Pointer) bool) bool {
+	if n.present {
+		if !yield(Pointer{tokens: slices.Clone(tokens)}) {
+			return false
+		}
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(n.children)) {
+		child := n.children[field]
+		if !child.walk(append(tokens, child.tok), yield) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package jsonpointer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	t.Parallel()
+
+	var trie Trie
+
+	ptrs := []string{
+		"/a",
+		"/a/b",
+		"/a/c/0",
+		"/a~1b",
+		"/d",
+	}
+
+	for _, ptr := range ptrs {
+		if !trie.Add(MustParse(ptr)) {
+			t.Errorf("Trie.Add(%s) = false, want true", ptr)
+		}
+	}
+
+	if trie.Add(MustParse("/a")) {
+		t.Errorf("Trie.Add(/a) = true, want false")
+	}
+
+	if trie.Len() != len(ptrs) {
+		t.Errorf("Trie.Len() = %d, want %d", trie.Len(), len(ptrs))
+	}
+
+	if !trie.Contains(MustParse("/a~1b")) {
+		t.Errorf("Trie.Contains(/a~1b) = false, want true")
+	}
+
+	if trie.Contains(MustParse("/a/c")) {
+		t.Errorf("Trie.Contains(/a/c) = true, want false")
+	}
+
+	if !trie.ContainsPrefixOf(MustParse("/a/c/1")) {
+		t.Errorf("Trie.ContainsPrefixOf(/a/c/1) = false, want true")
+	}
+
+	if trie.ContainsPrefixOf(MustParse("/e/a")) {
+		t.Errorf("Trie.ContainsPrefixOf(/e/a) = true, want false")
+	}
+
+	match, ok := trie.LongestMatch(MustParse("/a/b/c"))
+	if !ok || match.String() != "/a/b" {
+		t.Errorf("Trie.LongestMatch(/a/b/c) = (%s, %t), want (/a/b, true)", match, ok)
+	}
+
+	var descendants []string
+	for p := range trie.Descendants(MustParse("/a")) {
+		descendants = append(descendants, p.String())
+	}
+
+	want := []string{"/a", "/a/b", "/a/c/0"}
+	if !slices.Equal(descendants, want) {
+		t.Errorf("Trie.Descendants(/a) = %v, want %v", descendants, want)
+	}
+
+	if !trie.Remove(MustParse("/a/c/0")) {
+		t.Errorf("Trie.Remove(/a/c/0) = false, want true")
+	}
+
+	if trie.Remove(MustParse("/a/c")) {
+		t.Errorf("Trie.Remove(/a/c) = true, want false")
+	}
+
+	var all []string
+	for p := range trie.All() {
+		all = append(all, p.String())
+	}
+
+	want = []string{"/a", "/a/b", "/a~1b", "/d"}
+	if !slices.Equal(all, want) {
+		t.Errorf("Trie.All() = %v, want %v", all, want)
+	}
+}
@@ -0,0 +1,77 @@
+package jsonpointer
+
+import (
+	"reflect"
+)
+
+// CheckType reports whether the JSON pointer p can resolve against a value of
+// type t, without needing a value of that type. Struct fields must exist,
+// indices into Go arrays must be within their length, and reference tokens
+// used as map keys must be convertible to the map's key type. CheckType
+// returns the type of the value p would resolve to.
+//
+// The types of values held in interfaces, such as any, and in
+// [json.RawMessage] values are not known until a value is resolved, so if p
+// continues past one, CheckType stops checking and returns its type.
+// CheckType returns an error matching [ErrUnsupportedValue] if t is nil.
+func (p Pointer) CheckType(t reflect.Type) (reflect.Type, error) {
+	if t == nil {
+		return nil, &unsupportedValueError{t}
+	}
+
+	for _, tok := range p.tokens {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if t.Kind() == reflect.Interface || t == rawMessageType {
+			return t, nil
+		}
+
+		var err error
+		t, err = typeStep(t, tok)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// typeStep returns the type of the value that tok refers to within a value of
+// type t, which must not be a pointer or interface type.
+func typeStep(t reflect.Type, tok token) (reflect.Type, error) {
+	switch t.Kind() {
+	case reflect.Array:
+		if tok.index == -1 {
+			return nil, &invalidArrayIndexError{tok.field}
+		}
+
+		if tok.index >= t.Len() {
+			return nil, &arrayIndexOutOfBoundsError{tok.index}
+		}
+
+		return t.Elem(), nil
+	case reflect.Slice:
+		if tok.index == -1 {
+			return nil, &invalidArrayIndexError{tok.field}
+		}
+
+		return t.Elem(), nil
+	case reflect.Map:
+		if _, ok := mapKey(t.Key(), tok.field); !ok {
+			return nil, &valueNotFoundError{tok.field}
+		}
+
+		return t.Elem(), nil
+	case reflect.Struct:
+		index, ok := getStructFields(t)[tok.field]
+		if !ok {
+			return nil, &valueNotFoundError{tok.field}
+		}
+
+		return t.FieldByIndex(index).Type, nil
+	default:
+		return nil, &valueNotFoundError{tok.field}
+	}
+}
@@ -0,0 +1,68 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPointerCheckType(t *testing.T) {
+	t.Parallel()
+
+	type Host struct {
+		Port int `json:"port"`
+	}
+
+	type Config struct {
+		Hosts []*Host           `json:"hosts"`
+		Pair  [2]Host           `json:"pair"`
+		ByID  map[int]Host      `json:"by_id"`
+		Names map[string]string `json:"names"`
+		Extra any               `json:"extra"`
+		Raw   json.RawMessage   `json:"raw"`
+	}
+
+	type test struct {
+		ptr  string
+		want reflect.Type
+		err  error
+	}
+
+	tests := []test{
+		{"", reflect.TypeFor[*Config](), nil},
+		{"/hosts", reflect.TypeFor[[]*Host](), nil},
+		{"/hosts/3/port", reflect.TypeFor[int](), nil},
+		{"/hosts/-", nil, ErrInvalidArrayIndex},
+		{"/hosts/x", nil, ErrInvalidArrayIndex},
+		{"/pair/1/port", reflect.TypeFor[int](), nil},
+		{"/pair/2/port", nil, ErrArrayIndexOutOfBounds},
+		{"/by_id/12/port", reflect.TypeFor[int](), nil},
+		{"/by_id/x/port", nil, ErrValueNotFound},
+		{"/names/x", reflect.TypeFor[string](), nil},
+		{"/names/x/y", nil, ErrValueNotFound},
+		{"/extra/a/b", reflect.TypeFor[any](), nil},
+		{"/raw/a/b", reflect.TypeFor[json.RawMessage](), nil},
+		{"/missing", nil, ErrValueNotFound},
+	}
+
+	for _, test := range tests {
+		typ, err := MustParse(test.ptr).CheckType(reflect.TypeFor[*Config]())
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("Pointer(%s).CheckType() = %v, want %v", test.ptr, err, test.err)
+			}
+
+			continue
+		}
+
+		if typ != test.want || err != nil {
+			t.Errorf("Pointer(%s).CheckType() = (%v, %v), want (%v, <nil>)", test.ptr, typ, err, test.want)
+		}
+	}
+
+	_, err := MustParse("/a").CheckType(reflect.TypeOf(nil))
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Pointer.CheckType(nil) = %v, want %v", err, ErrUnsupportedValue)
+	}
+}
@@ -0,0 +1,127 @@
+package jsonpointer
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Unbind is the inverse of [Bind]. Unbind builds a generic JSON tree of
+// map[string]any and []any values in which the value of each field of the
+// struct src, or the struct that src points to, with a tag of the form
+// `jsonpointer:"/a/b"` is placed at the JSON pointer in the tag. Objects and
+// arrays along the pointers are created as they are by [Pointer.SetCreate]
+// with the [CreateArrays] option. Field values are themselves converted to
+// generic trees, naming struct fields by their JSON names and encoding and
+// decoding values that implement [json.Marshaler]. Tag options are ignored.
+//
+// Fields are placed in order of their pointers, comparing array indices by
+// their numeric value, so that the elements of an array may be given by
+// fields in any order. Unbind returns an error matching
+// [ErrOverlappingPointers] if the pointer of one field is the same as, or a
+// prefix of, the pointer of another.
+func Unbind(src any) (any, error) {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, &unsupportedValueError{reflect.TypeOf(src)}
+	}
+
+	fields := slices.Clone(getBindFields(v.Type()))
+	slices.SortStableFunc(fields, func(a, b bindField) int {
+		return comparePointers(a.ptr, b.ptr)
+	})
+
+	var t Trie
+	var errs []error
+	for _, f := range fields {
+		if f.err != nil {
+			errs = append(errs, &bindError{f.name, f.tag, f.err})
+			continue
+		}
+
+		if other, ok := t.LongestMatch(f.ptr); ok {
+			errs = append(errs, &overlappingPointersError{other.String(), f.ptr.String()})
+			continue
+		}
+
+		var overlap bool
+		for other := range t.Descendants(f.ptr) {
+			errs = append(errs, &overlappingPointersError{f.ptr.String(), other.String()})
+			overlap = true
+			break
+		}
+
+		if overlap {
+			continue
+		}
+
+		t.Add(f.ptr)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	var doc any = map[string]any{}
+	for _, f := range fields {
+		value, err := toGeneric(v.Field(f.index))
+		if err != nil {
+			errs = append(errs, &bindError{f.name, f.tag, err})
+			continue
+		}
+
+		if err := f.ptr.SetCreate(&doc, value, CreateArrays()); err != nil {
+			errs = append(errs, &bindError{f.name, f.tag, err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return doc, nil
+}
+
+// MarshalJSON returns the JSON encoding of the document built from src by
+// [Unbind]. It can be used to implement [json.Marshaler] for a struct whose
+// fields carry pointer tags:
+//
+//	func (e Event) MarshalJSON() ([]byte, error) {
+//		return jsonpointer.MarshalJSON(e)
+//	}
+func MarshalJSON(src any) ([]byte, error) {
+	doc, err := Unbind(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// comparePointers orders JSON pointers by their reference tokens, comparing
+// array indices by their numeric value.
+func comparePointers(a, b Pointer) int {
+	for i := range min(len(a.tokens), len(b.tokens)) {
+		x, y := a.tokens[i], b.tokens[i]
+		if x.index != -1 && y.index != -1 {
+			if c := cmp.Compare(x.index, y.index); c != 0 {
+				return c
+			}
+
+			continue
+		}
+
+		if c := strings.Compare(x.field, y.field); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(a.tokens), len(b.tokens))
+}
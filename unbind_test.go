@@ -0,0 +1,108 @@
+package jsonpointer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type unbindEvent struct {
+	OwnerID  int64    `jsonpointer:"/meta/owner/id"`
+	Second   string   `jsonpointer:"/items/1/name"`
+	First    string   `jsonpointer:"/items/0/name"`
+	Tags     []string `jsonpointer:"/meta/tags"`
+	Escaped  bool     `jsonpointer:"/a~1b"`
+	Untagged string
+}
+
+func (e unbindEvent) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(e)
+}
+
+func TestUnbind(t *testing.T) {
+	t.Parallel()
+
+	e := unbindEvent{
+		OwnerID:  42,
+		Second:   "b",
+		First:    "a",
+		Tags:     []string{"c"},
+		Escaped:  true,
+		Untagged: "d",
+	}
+
+	doc, err := Unbind(&e)
+	if err != nil {
+		t.Fatalf("Unbind() = %v, want <nil>", err)
+	}
+
+	want := map[string]any{
+		"meta": map[string]any{
+			"owner": map[string]any{
+				"id": int64(42),
+			},
+			"tags": []any{"c"},
+		},
+		"items": []any{
+			map[string]any{"name": "a"},
+			map[string]any{"name": "b"},
+		},
+		"a/b": true,
+	}
+
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("Unbind() = %v, want %v", doc, want)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want <nil>", err)
+	}
+
+	wantData := `{"a/b":true,"items":[{"name":"a"},{"name":"b"}],"meta":{"owner":{"id":42},"tags":["c"]}}`
+	if string(data) != wantData {
+		t.Errorf("json.Marshal() = %s, want %s", data, wantData)
+	}
+
+	var bound unbindEvent
+	if err := Bind(doc, &bound); err != nil {
+		t.Fatalf("Bind() = %v, want <nil>", err)
+	}
+
+	e.Untagged = ""
+	if !reflect.DeepEqual(bound, e) {
+		t.Errorf("Bind(Unbind()) = %+v, want %+v", bound, e)
+	}
+
+	type Overlapping struct {
+		A string `jsonpointer:"/a/b"`
+		B string `jsonpointer:"/a"`
+		C string `jsonpointer:"/c"`
+		D string `jsonpointer:"/c"`
+	}
+
+	_, err = Unbind(Overlapping{})
+	if !errors.Is(err, ErrOverlappingPointers) {
+		t.Errorf("Unbind() = %v, want %v", err, ErrOverlappingPointers)
+	}
+
+	if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 2 {
+		t.Errorf("Unbind() returned %d errors, want 2", len(errs))
+	}
+
+	type Gap struct {
+		A string `jsonpointer:"/a/0"`
+		B string `jsonpointer:"/a/2"`
+	}
+
+	_, err = Unbind(Gap{})
+	if !errors.Is(err, ErrArrayIndexOutOfBounds) {
+		t.Errorf("Unbind() = %v, want %v", err, ErrArrayIndexOutOfBounds)
+	}
+
+	_, err = Unbind(1)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Unbind() = %v, want %v", err, ErrUnsupportedValue)
+	}
+}